@@ -14,6 +14,8 @@ import (
 
 var schema *Schema
 
+var dataPlaneSchema *Schema
+
 //go:embed generated
 var StaticFiles embed.FS
 
@@ -23,22 +25,54 @@ func GetAzureSchema() *Schema {
 	mutex.Lock()
 	defer mutex.Unlock()
 	if schema == nil {
-		data, err := StaticFiles.ReadFile("generated/index.json")
-		if err != nil {
-			log.Printf("[ERROR] failed to load schema index: %+v", err)
-			return nil
-		}
-		err = json.Unmarshal(data, &schema)
-		if err != nil {
-			log.Printf("[ERROR] failed to unmarshal schema index: %+v", err)
-			return nil
-		}
+		schema = loadSchema("generated/index.json")
 	}
 	return schema
 }
 
+// GetDataPlaneAzureSchema returns the schema of the data plane resources, it only contains the resource types whose
+// definitions are embedded, the other data plane resource types are not included.
+func GetDataPlaneAzureSchema() *Schema {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if dataPlaneSchema == nil {
+		dataPlaneSchema = loadSchema("generated/dataplane/index.json")
+	}
+	return dataPlaneSchema
+}
+
+func loadSchema(path string) *Schema {
+	data, err := StaticFiles.ReadFile(path)
+	if err != nil {
+		log.Printf("[ERROR] failed to load schema index: %+v", err)
+		return nil
+	}
+	var out *Schema
+	err = json.Unmarshal(data, &out)
+	if err != nil {
+		log.Printf("[ERROR] failed to unmarshal schema index: %+v", err)
+		return nil
+	}
+	return out
+}
+
 func GetApiVersions(resourceType string) []string {
-	azureSchema := GetAzureSchema()
+	return apiVersions(GetAzureSchema(), resourceType)
+}
+
+func GetResourceDefinition(resourceType, apiVersion string) (*types.ResourceType, error) {
+	return resourceDefinition(GetAzureSchema(), resourceType, apiVersion)
+}
+
+func GetDataPlaneApiVersions(resourceType string) []string {
+	return apiVersions(GetDataPlaneAzureSchema(), resourceType)
+}
+
+func GetDataPlaneResourceDefinition(resourceType, apiVersion string) (*types.ResourceType, error) {
+	return resourceDefinition(GetDataPlaneAzureSchema(), resourceType, apiVersion)
+}
+
+func apiVersions(azureSchema *Schema, resourceType string) []string {
 	if azureSchema == nil {
 		return []string{}
 	}
@@ -54,8 +88,7 @@ func GetApiVersions(resourceType string) []string {
 	return res
 }
 
-func GetResourceDefinition(resourceType, apiVersion string) (*types.ResourceType, error) {
-	azureSchema := GetAzureSchema()
+func resourceDefinition(azureSchema *Schema, resourceType, apiVersion string) (*types.ResourceType, error) {
 	if azureSchema == nil {
 		return nil, fmt.Errorf("failed to load azure schema index")
 	}
@@ -70,3 +103,28 @@ func GetResourceDefinition(resourceType, apiVersion string) (*types.ResourceType
 	}
 	return nil, fmt.Errorf("failed to find resource type %s api-version %s in azure schema index", resourceType, apiVersion)
 }
+
+func GetResourceFunctionDefinitions(resourceType, apiVersion string) ([]*types.ResourceFunctionType, error) {
+	azureSchema := GetAzureSchema()
+	if azureSchema == nil {
+		return nil, fmt.Errorf("failed to load azure schema index")
+	}
+	res := make([]*types.ResourceFunctionType, 0)
+	for key, value := range azureSchema.Functions {
+		if strings.EqualFold(key, resourceType) {
+			for i := range value.Definitions {
+				if value.Definitions[i].ApiVersion != apiVersion {
+					continue
+				}
+				definition, err := value.Definitions[i].GetDefinition()
+				if err != nil {
+					return nil, err
+				}
+				if definition != nil {
+					res = append(res, definition)
+				}
+			}
+		}
+	}
+	return res, nil
+}
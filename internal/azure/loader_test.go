@@ -64,3 +64,36 @@ func Test_AllBicepTypes(t *testing.T) {
 		}
 	}
 }
+
+func Test_AllDataPlaneTypes(t *testing.T) {
+	schema := azure.GetDataPlaneAzureSchema()
+	if schema == nil {
+		t.Fatal("failed to load data plane schema")
+	}
+	if len(schema.Resources) == 0 {
+		t.Fatal("expect resources are not empty")
+	}
+	for resourceName, res := range schema.Resources {
+		for _, definition := range res.Definitions {
+			def, err := definition.GetDefinition()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if def == nil {
+				t.Fatalf("failed to load resource definition for %s api-version %s", resourceName, definition.ApiVersion)
+			}
+		}
+	}
+}
+
+func Test_GetDataPlaneApiVersions(t *testing.T) {
+	case1 := "Microsoft.AppConfiguration/configurationStores/keyValues"
+	if len(azure.GetDataPlaneApiVersions(case1)) == 0 {
+		t.Errorf("expect multiple api-version but got 0 for %s", case1)
+	}
+
+	case2 := "Microsoft.AppConfiguration/configurationStores"
+	if len(azure.GetDataPlaneApiVersions(case2)) != 0 {
+		t.Errorf("expect 0 api-version but got multiple for %s", case2)
+	}
+}
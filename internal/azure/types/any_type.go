@@ -21,3 +21,10 @@ func (t *AnyType) GetWriteOnly(i interface{}) interface{} {
 	}
 	return i
 }
+
+func (t *AnyType) SelectValues(body interface{}, selector ValueSelector) interface{} {
+	if t == nil || body == nil || !selector.selectsType(t) {
+		return nil
+	}
+	return body
+}
@@ -75,3 +75,29 @@ func (t *ArrayType) AsTypeBase() *TypeBase {
 	typeBase := TypeBase(t)
 	return &typeBase
 }
+
+// SelectValues returns an array with the same length as the body, the items which don't contain selected values are nil.
+func (t *ArrayType) SelectValues(body interface{}, selector ValueSelector) interface{} {
+	if t == nil || body == nil || t.ItemType == nil || t.ItemType.Type == nil {
+		return nil
+	}
+	if selector.selectsType(t) {
+		return body
+	}
+	bodyArray, ok := body.([]interface{})
+	if !ok {
+		return nil
+	}
+	found := false
+	res := make([]interface{}, len(bodyArray))
+	for i, value := range bodyArray {
+		res[i] = (*t.ItemType.Type).SelectValues(value, selector)
+		if res[i] != nil {
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return res
+}
@@ -127,3 +127,47 @@ func (t *DiscriminatedObjectType) AsTypeBase() *TypeBase {
 	typeBase := TypeBase(t)
 	return &typeBase
 }
+
+func (t *DiscriminatedObjectType) SelectValues(body interface{}, selector ValueSelector) interface{} {
+	if t == nil || body == nil {
+		return nil
+	}
+	if selector.selectsType(t) {
+		return body
+	}
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	res := make(map[string]interface{})
+	for key, def := range t.BaseProperties {
+		value, ok := bodyMap[key]
+		if !ok {
+			continue
+		}
+		if selector.selectsProperty(def) {
+			res[key] = value
+			continue
+		}
+		if def.Type != nil && def.Type.Type != nil {
+			if selected := (*def.Type.Type).SelectValues(value, selector); selected != nil {
+				res[key] = selected
+			}
+		}
+	}
+
+	if discriminator, ok := bodyMap[t.Discriminator].(string); ok {
+		if t.Elements[discriminator] != nil && t.Elements[discriminator].Type != nil {
+			if selected, ok := (*t.Elements[discriminator].Type).SelectValues(body, selector).(map[string]interface{}); ok {
+				for key, value := range selected {
+					res[key] = value
+				}
+			}
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
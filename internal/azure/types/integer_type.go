@@ -57,3 +57,10 @@ func (t *IntegerType) GetWriteOnly(i interface{}) interface{} {
 	}
 	return i
 }
+
+func (t *IntegerType) SelectValues(body interface{}, selector ValueSelector) interface{} {
+	if t == nil || body == nil || !selector.selectsType(t) {
+		return nil
+	}
+	return body
+}
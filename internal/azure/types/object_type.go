@@ -201,3 +201,43 @@ const (
 func PossibleObjectPropertyFlagValues() []ObjectPropertyFlag {
 	return []ObjectPropertyFlag{None, Required, ReadOnly, WriteOnly, DeployTimeConstant, Identifier}
 }
+
+// SelectValues returns the properties which are selected, it returns nil if there's no selected property.
+func (t *ObjectType) SelectValues(body interface{}, selector ValueSelector) interface{} {
+	if t == nil || body == nil {
+		return nil
+	}
+	if selector.selectsType(t) {
+		return body
+	}
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	res := make(map[string]interface{})
+	for key, value := range bodyMap {
+		var valueType *TypeBase
+		if def, ok := t.Properties[key]; ok {
+			if selector.selectsProperty(def) {
+				res[key] = value
+				continue
+			}
+			if def.Type != nil {
+				valueType = def.Type.Type
+			}
+		} else if t.AdditionalProperties != nil {
+			valueType = t.AdditionalProperties.Type
+		}
+		if valueType == nil {
+			continue
+		}
+		if selected := (*valueType).SelectValues(value, selector); selected != nil {
+			res[key] = selected
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
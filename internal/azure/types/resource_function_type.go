@@ -23,3 +23,7 @@ func (t ResourceFunctionType) Validate(body interface{}, path string) []error {
 func (t ResourceFunctionType) GetWriteOnly(body interface{}) interface{} {
 	return body
 }
+
+func (t ResourceFunctionType) SelectValues(body interface{}, selector ValueSelector) interface{} {
+	return nil
+}
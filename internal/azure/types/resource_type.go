@@ -198,3 +198,24 @@ const (
 func PossibleResourceTypeFlagValues() []ResourceTypeFlag {
 	return []ResourceTypeFlag{ResourceTypeFlagNone, ResourceTypeFlagReadOnly}
 }
+
+func (t *ResourceType) SelectValues(body interface{}, selector ValueSelector) interface{} {
+	if t == nil || body == nil {
+		return nil
+	}
+	if t.Body != nil && t.Body.Type != nil {
+		return (*t.Body.Type).SelectValues(body, selector)
+	}
+	return nil
+}
+
+// GetSensitive returns the sensitive values in the body, it returns nil if there's no sensitive value.
+func (t *ResourceType) GetSensitive(body interface{}) interface{} {
+	return t.SelectValues(body, SensitiveSelector)
+}
+
+// GetDeployTimeConstant returns the deploy-time constant properties in the body, which can't be changed after the
+// resource is created. It returns nil if there's no deploy-time constant property.
+func (t *ResourceType) GetDeployTimeConstant(body interface{}) interface{} {
+	return t.SelectValues(body, DeployTimeConstantSelector)
+}
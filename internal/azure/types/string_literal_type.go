@@ -39,3 +39,10 @@ func (t *StringLiteralType) AsTypeBase() *TypeBase {
 	typeBase := TypeBase(t)
 	return &typeBase
 }
+
+func (t *StringLiteralType) SelectValues(body interface{}, selector ValueSelector) interface{} {
+	if t == nil || body == nil || !selector.selectsType(t) {
+		return nil
+	}
+	return body
+}
@@ -53,3 +53,10 @@ func (s *StringType) GetWriteOnly(i interface{}) interface{} {
 	}
 	return i
 }
+
+func (s *StringType) SelectValues(body interface{}, selector ValueSelector) interface{} {
+	if s == nil || body == nil || !selector.selectsType(s) {
+		return nil
+	}
+	return body
+}
@@ -4,4 +4,5 @@ type TypeBase interface {
 	AsTypeBase() *TypeBase
 	Validate(interface{}, string) []error
 	GetWriteOnly(interface{}) interface{}
+	SelectValues(interface{}, ValueSelector) interface{}
 }
@@ -61,3 +61,18 @@ func (t *UnionType) AsTypeBase() *TypeBase {
 	typeBase := TypeBase(t)
 	return &typeBase
 }
+
+func (t *UnionType) SelectValues(body interface{}, selector ValueSelector) interface{} {
+	if t == nil || body == nil {
+		return nil
+	}
+	for _, element := range t.Elements {
+		if element == nil || element.Type == nil {
+			continue
+		}
+		if selected := (*element.Type).SelectValues(body, selector); selected != nil {
+			return selected
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package types
+
+// ValueSelector selects the values in a body by their types or by the definitions of the properties which hold them,
+// the selected values are returned as a whole.
+type ValueSelector struct {
+	// Type returns true if the values of the type are selected
+	Type func(TypeBase) bool
+	// Property returns true if the values of the object property are selected
+	Property func(ObjectProperty) bool
+}
+
+// SensitiveSelector selects the values whose types are marked as sensitive.
+var SensitiveSelector = ValueSelector{
+	Type: func(t TypeBase) bool {
+		switch v := t.(type) {
+		case *StringType:
+			return v.Sensitive
+		case *ObjectType:
+			return v.Sensitive
+		}
+		return false
+	},
+}
+
+// DeployTimeConstantSelector selects the writable properties which are marked as deploy-time constants.
+var DeployTimeConstantSelector = ValueSelector{
+	Property: func(def ObjectProperty) bool {
+		return def.IsDeployTimeConstant() && !def.IsReadOnly()
+	},
+}
+
+func (s ValueSelector) selectsType(t TypeBase) bool {
+	return s.Type != nil && s.Type(t)
+}
+
+func (s ValueSelector) selectsProperty(def ObjectProperty) bool {
+	return s.Property != nil && s.Property(def)
+}
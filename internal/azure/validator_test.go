@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
 	"github.com/Azure/terraform-provider-azapi/utils"
 )
 
@@ -215,6 +216,77 @@ func Test_BodyValidation(t *testing.T) {
 	}
 }
 
+func Test_DataPlaneBodyValidation(t *testing.T) {
+	testData := []struct {
+		ResourceType string
+		ApiVersion   string
+		Body         string
+		Error        bool
+	}{
+		{
+			ResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues",
+			ApiVersion:   "1.0",
+			Body:         `{"content_type": "", "value": "myvalue", "tags": {"env": "test"}}`,
+			Error:        false,
+		},
+		{
+			ResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues",
+			ApiVersion:   "1.0",
+			Body:         `{"contentType": "", "value": "myvalue"}`,
+			Error:        true, // contentType is not defined
+		},
+		{
+			ResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues",
+			ApiVersion:   "1.0",
+			Body:         `{"value": "myvalue", "locked": true}`,
+			Error:        true, // locked is read-only
+		},
+		{
+			ResourceType: "Microsoft.Synapse/workspaces/pipelines",
+			ApiVersion:   "2020-12-01",
+			Body:         `{"properties": {"activities": [{"name": "wait", "type": "Wait", "typeProperties": {"waitTimeInSeconds": 10}}], "parameters": {"path": {"type": "String"}}}}`,
+			Error:        false,
+		},
+		{
+			ResourceType: "Microsoft.Synapse/workspaces/pipelines",
+			ApiVersion:   "2020-12-01",
+			Body:         `{"properties": {"activites": []}}`,
+			Error:        true, // activites is not defined
+		},
+		{
+			ResourceType: "Microsoft.Synapse/workspaces/linkedservices",
+			ApiVersion:   "2020-12-01",
+			Body:         `{"properties": {"type": "AzureBlobStorage", "typeProperties": {"connectionString": "xxx"}}}`,
+			Error:        false,
+		},
+		{
+			ResourceType: "Microsoft.Synapse/workspaces/datasets",
+			ApiVersion:   "2020-12-01",
+			Body:         `{"properties": {"type": "Json"}}`,
+			Error:        true, // properties.linkedServiceName is required
+		},
+	}
+
+	for index, data := range testData {
+		var body interface{}
+		_ = json.Unmarshal([]byte(data.Body), &body)
+
+		def, err := azure.GetDataPlaneResourceDefinition(data.ResourceType, data.ApiVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if def == nil {
+			t.Fatalf("failed to load resource definition for type: %s, api-version: %s", data.ResourceType, data.ApiVersion)
+		}
+
+		errors := (*def).Validate(body, "")
+		t.Logf("[DEBUG] Running test for case %d, resource type: %s, api-version: %s: %v", index, data.ResourceType, data.ApiVersion, errors)
+		if (len(errors) > 0) != data.Error {
+			t.Errorf("expect error: %t, got error: %t for type: %s, api-version: %s", data.Error, len(errors) > 0, data.ResourceType, data.ApiVersion)
+		}
+	}
+}
+
 func Test_WriteOnly(t *testing.T) {
 	testData := []struct {
 		Id         string
@@ -504,3 +576,69 @@ func Test_WriteOnly(t *testing.T) {
 		}
 	}
 }
+
+func Test_DeployTimeConstant(t *testing.T) {
+	stringType := (&types.StringType{}).AsTypeBase()
+	subnetType := &types.ObjectType{
+		Properties: map[string]types.ObjectProperty{
+			"name":          {Type: &types.TypeReference{Type: stringType}},
+			"addressPrefix": {Type: &types.TypeReference{Type: stringType}, Flags: []types.ObjectPropertyFlag{types.DeployTimeConstant}},
+		},
+	}
+	bodyType := &types.ObjectType{
+		Properties: map[string]types.ObjectProperty{
+			"id":   {Type: &types.TypeReference{Type: stringType}, Flags: []types.ObjectPropertyFlag{types.ReadOnly, types.DeployTimeConstant}},
+			"kind": {Type: &types.TypeReference{Type: stringType}, Flags: []types.ObjectPropertyFlag{types.DeployTimeConstant}},
+			"properties": {Type: &types.TypeReference{Type: (&types.ObjectType{
+				Properties: map[string]types.ObjectProperty{
+					"description": {Type: &types.TypeReference{Type: stringType}},
+					"subnets": {Type: &types.TypeReference{Type: (&types.ArrayType{
+						ItemType: &types.TypeReference{Type: subnetType.AsTypeBase()},
+					}).AsTypeBase()}},
+				},
+			}).AsTypeBase()}},
+		},
+	}
+	def := &types.ResourceType{Body: &types.TypeReference{Type: bodyType.AsTypeBase()}}
+
+	var body interface{}
+	_ = json.Unmarshal([]byte(`
+{
+    "id": "/subscriptions/00000000-0000-0000-0000-000000000000",
+    "kind": "StorageV2",
+    "properties": {
+        "description": "example",
+        "subnets": [
+            {
+                "name": "subnet1",
+                "addressPrefix": "10.0.0.0/24"
+            },
+            {
+                "name": "subnet2"
+            }
+        ]
+    }
+}
+`), &body)
+	var expected interface{}
+	_ = json.Unmarshal([]byte(`
+{
+    "kind": "StorageV2",
+    "properties": {
+        "subnets": [
+            {
+                "addressPrefix": "10.0.0.0/24"
+            },
+            null
+        ]
+    }
+}
+`), &expected)
+
+	actual := def.GetDeployTimeConstant(body)
+	if !reflect.DeepEqual(actual, expected) {
+		expectedJson, _ := json.Marshal(expected)
+		actualJson, _ := json.Marshal(actual)
+		t.Fatalf("expected %s, got %s", expectedJson, actualJson)
+	}
+}
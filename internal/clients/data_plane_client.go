@@ -37,56 +37,68 @@ func NewDataPlaneClient(credential azcore.TokenCredential, opt *arm.ClientOption
 	}, nil
 }
 
-func (client *DataPlaneClient) cachedPipeline(rawUrl string) (runtime.Pipeline, error) {
+// cachedPipeline returns the pipeline used to send requests to the url. If the audience is specified, the access token
+// is requested for it, otherwise the audience is determined by matching the host with the known cloud services.
+func (client *DataPlaneClient) cachedPipeline(rawUrl string, audience string) (runtime.Pipeline, error) {
 	client.syncMux.Lock()
 	defer client.syncMux.Unlock()
 
-	parsedUrl, err := url.Parse(rawUrl)
-	if err != nil {
-		return runtime.Pipeline{}, err
-	}
-	serviceName := cloud.ResourceManager
-	cloud := client.clientOptions.Cloud
-	host := parsedUrl.Host
-	for name, serviceConfiguration := range cloud.Services {
-		if strings.HasSuffix(host, strings.TrimPrefix(serviceConfiguration.Endpoint, "https://")) {
-			serviceName = name
-			break
+	cacheKey := audience
+	if audience == "" {
+		parsedUrl, err := url.Parse(rawUrl)
+		if err != nil {
+			return runtime.Pipeline{}, err
+		}
+		serviceName := cloud.ResourceManager
+		cloud := client.clientOptions.Cloud
+		host := parsedUrl.Host
+		for name, serviceConfiguration := range cloud.Services {
+			if strings.HasSuffix(host, strings.TrimPrefix(serviceConfiguration.Endpoint, "https://")) {
+				serviceName = name
+				break
+			}
 		}
+		cacheKey = string(serviceName)
+		audience = cloud.Services[serviceName].Audience
 	}
 
-	if pipeline, ok := client.cachedPipelines[string(serviceName)]; ok {
+	if pipeline, ok := client.cachedPipelines[cacheKey]; ok {
 		return pipeline, nil
 	}
 
 	plOpt := runtime.PipelineOptions{}
 	plOpt.APIVersion.Name = "api-version"
-	authPolicy := armruntime.NewBearerTokenPolicy(client.credential, &armpolicy.BearerTokenOptions{Scopes: []string{cloud.Services[serviceName].Audience + "/.default"}})
+	authPolicy := armruntime.NewBearerTokenPolicy(client.credential, &armpolicy.BearerTokenOptions{Scopes: []string{strings.TrimSuffix(audience, "/") + "/.default"}})
 	plOpt.PerRetry = append(plOpt.PerRetry, authPolicy)
 	pl := runtime.NewPipeline(moduleName, moduleVersion, plOpt, &client.clientOptions.ClientOptions)
 
-	client.cachedPipelines[string(serviceName)] = pl
+	client.cachedPipelines[cacheKey] = pl
 	return pl, nil
 }
 
-func (client *DataPlaneClient) CreateOrUpdateThenPoll(ctx context.Context, id parse.DataPlaneResourceId, body interface{}) (interface{}, error) {
+func (client *DataPlaneClient) CreateOrUpdateThenPoll(ctx context.Context, id parse.DataPlaneResourceId, body interface{}, options RequestOptions) (interface{}, error) {
+	return withRetry(ctx, options.Retry, func() (interface{}, error) {
+		return client.createOrUpdateThenPoll(ctx, id, body, options)
+	})
+}
+
+func (client *DataPlaneClient) createOrUpdateThenPoll(ctx context.Context, id parse.DataPlaneResourceId, body interface{}, options RequestOptions) (interface{}, error) {
 	// build request
 	urlPath := fmt.Sprintf("https://%s", id.AzureResourceId)
 	req, err := runtime.NewRequest(ctx, http.MethodPut, urlPath)
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", id.ApiVersion)
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().URL.RawQuery = options.query(id.ApiVersion).Encode()
 	req.Raw().Header.Set("Accept", "application/json")
+	options.setHeaders(req)
 	err = runtime.MarshalAsJSON(req, body)
 	if err != nil {
 		return nil, err
 	}
 
 	// send request
-	pipeline, err := client.cachedPipeline(urlPath)
+	pipeline, err := client.cachedPipeline(urlPath, parse.DataPlaneAudience(id.AzureResourceType))
 	if err != nil {
 		return nil, err
 	}
@@ -115,23 +127,22 @@ func (client *DataPlaneClient) CreateOrUpdateThenPoll(ctx context.Context, id pa
 	return responseBody, nil
 }
 
-func (client *DataPlaneClient) Get(ctx context.Context, id parse.DataPlaneResourceId) (interface{}, error) {
+func (client *DataPlaneClient) Get(ctx context.Context, id parse.DataPlaneResourceId, options RequestOptions) (interface{}, error) {
 	// build request
 	urlPath := fmt.Sprintf("https://%s", id.AzureResourceId)
 	req, err := runtime.NewRequest(ctx, http.MethodGet, urlPath)
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", id.ApiVersion)
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().URL.RawQuery = options.query(id.ApiVersion).Encode()
 	req.Raw().Header.Set("Accept", "application/json")
+	options.setHeaders(req)
 
 	// send request
 	if err != nil {
 		return nil, err
 	}
-	pipeline, err := client.cachedPipeline(urlPath)
+	pipeline, err := client.cachedPipeline(urlPath, parse.DataPlaneAudience(id.AzureResourceType))
 	if err != nil {
 		return nil, err
 	}
@@ -151,23 +162,28 @@ func (client *DataPlaneClient) Get(ctx context.Context, id parse.DataPlaneResour
 	return responseBody, nil
 }
 
-func (client *DataPlaneClient) DeleteThenPoll(ctx context.Context, id parse.DataPlaneResourceId) (interface{}, error) {
+func (client *DataPlaneClient) DeleteThenPoll(ctx context.Context, id parse.DataPlaneResourceId, options RequestOptions) (interface{}, error) {
+	return withRetry(ctx, options.Retry, func() (interface{}, error) {
+		return client.deleteThenPoll(ctx, id, options)
+	})
+}
+
+func (client *DataPlaneClient) deleteThenPoll(ctx context.Context, id parse.DataPlaneResourceId, options RequestOptions) (interface{}, error) {
 	// build request
 	urlPath := fmt.Sprintf("https://%s", id.AzureResourceId)
 	req, err := runtime.NewRequest(ctx, http.MethodDelete, urlPath)
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", id.ApiVersion)
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().URL.RawQuery = options.query(id.ApiVersion).Encode()
 	req.Raw().Header.Set("Accept", "application/json")
+	options.setHeaders(req)
 
 	// send request
 	if err != nil {
 		return nil, err
 	}
-	pipeline, err := client.cachedPipeline(urlPath)
+	pipeline, err := client.cachedPipeline(urlPath, parse.DataPlaneAudience(id.AzureResourceType))
 	if err != nil {
 		return nil, err
 	}
@@ -196,20 +212,25 @@ func (client *DataPlaneClient) DeleteThenPoll(ctx context.Context, id parse.Data
 	return responseBody, nil
 }
 
-func (client *DataPlaneClient) Action(ctx context.Context, resourceID string, action string, apiVersion string, method string, body interface{}) (interface{}, error) {
+func (client *DataPlaneClient) Action(ctx context.Context, id parse.DataPlaneResourceId, action string, method string, body interface{}, options RequestOptions) (interface{}, error) {
+	return withRetry(ctx, options.Retry, func() (interface{}, error) {
+		return client.action(ctx, id, action, method, body, options)
+	})
+}
+
+func (client *DataPlaneClient) action(ctx context.Context, id parse.DataPlaneResourceId, action string, method string, body interface{}, options RequestOptions) (interface{}, error) {
 	// build request
-	urlPath := fmt.Sprintf("https://%s", resourceID)
+	urlPath := fmt.Sprintf("https://%s", id.AzureResourceId)
 	if action != "" {
-		urlPath = fmt.Sprintf("%s/%s", resourceID, action)
+		urlPath = fmt.Sprintf("%s/%s", urlPath, action)
 	}
 	req, err := runtime.NewRequest(ctx, method, urlPath)
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", apiVersion)
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().URL.RawQuery = options.query(id.ApiVersion).Encode()
 	req.Raw().Header.Set("Accept", "application/json")
+	options.setHeaders(req)
 	if method != "GET" && body != nil {
 		err = runtime.MarshalAsJSON(req, body)
 	}
@@ -218,7 +239,7 @@ func (client *DataPlaneClient) Action(ctx context.Context, resourceID string, ac
 	}
 
 	// send request
-	pipeline, err := client.cachedPipeline(urlPath)
+	pipeline, err := client.cachedPipeline(urlPath, parse.DataPlaneAudience(id.AzureResourceType))
 	if err != nil {
 		return nil, err
 	}
@@ -226,7 +247,7 @@ func (client *DataPlaneClient) Action(ctx context.Context, resourceID string, ac
 	if err != nil {
 		return nil, err
 	}
-	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated, http.StatusAccepted) {
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent) {
 		return nil, runtime.NewResponseError(resp)
 	}
 
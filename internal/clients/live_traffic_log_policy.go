@@ -5,10 +5,14 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+	"github.com/Azure/terraform-provider-azapi/utils"
 )
 
 const redactedValue = "REDACTED"
@@ -45,11 +49,12 @@ func NewLiveTrafficLogPolicy() policy.Policy {
 
 func (p *liveTrafficLogPolicy) Do(req *policy.Request) (*http.Response, error) {
 	rawRequest := req.Raw()
+	requestBodyType, responseBodyType := sensitiveBodyTypes(rawRequest.URL)
 	liveReq := liveRequest{
 		Headers: p.header(rawRequest.Header),
 		Method:  rawRequest.Method,
 		Url:     rawRequest.URL.String(),
-		Body:    p.requestBodyString(req),
+		Body:    redactBody(p.requestBodyString(req), requestBodyType),
 	}
 	if err := req.RewindBody(); err != nil {
 		return nil, err
@@ -59,7 +64,7 @@ func (p *liveTrafficLogPolicy) Do(req *policy.Request) (*http.Response, error) {
 	if err == nil {
 		liveResp.Headers = p.header(response.Header)
 		liveResp.StatusCode = response.StatusCode
-		liveResp.Body = p.responseBodyString(response)
+		liveResp.Body = redactBody(p.responseBodyString(response), responseBodyType)
 	} else {
 		liveResp.Body = err.Error()
 	}
@@ -114,3 +119,69 @@ func (p *liveTrafficLogPolicy) header(input http.Header) map[string]string {
 	}
 	return output
 }
+
+// sensitiveBodyTypes returns the types of the request body and response body which are defined in the embedded schema,
+// they are used to redact the sensitive values. It supports the requests sent to a resource or a resource function.
+func sensitiveBodyTypes(input *url.URL) (*types.TypeBase, *types.TypeBase) {
+	if input == nil {
+		return nil, nil
+	}
+	apiVersion := input.Query().Get("api-version")
+	if apiVersion == "" {
+		return nil, nil
+	}
+	azureResourceId := strings.TrimSuffix(input.Path, "/")
+	segments := strings.Split(strings.TrimPrefix(azureResourceId, "/"), "/")
+	if len(segments)%2 == 0 {
+		resourceDef, err := azure.GetResourceDefinition(utils.GetResourceType(azureResourceId), apiVersion)
+		if err != nil || resourceDef == nil || resourceDef.Body == nil {
+			return nil, nil
+		}
+		return resourceDef.Body.Type, resourceDef.Body.Type
+	}
+
+	// the last segment is the name of the resource function, e.g. `listKeys`
+	index := strings.LastIndex(azureResourceId, "/")
+	parentId := azureResourceId[:index]
+	if parentId == "" {
+		parentId = "/"
+	}
+	functionDefs, err := azure.GetResourceFunctionDefinitions(utils.GetResourceType(parentId), apiVersion)
+	if err != nil {
+		return nil, nil
+	}
+	for _, functionDef := range functionDefs {
+		if !strings.EqualFold(functionDef.Name, azureResourceId[index+1:]) {
+			continue
+		}
+		var requestBodyType, responseBodyType *types.TypeBase
+		if functionDef.Input != nil {
+			requestBodyType = functionDef.Input.Type
+		}
+		if functionDef.Output != nil {
+			responseBodyType = functionDef.Output.Type
+		}
+		return requestBodyType, responseBodyType
+	}
+	return nil, nil
+}
+
+// redactBody replaces the sensitive values in the JSON body with the redacted value.
+func redactBody(body string, bodyType *types.TypeBase) string {
+	if body == "" || bodyType == nil {
+		return body
+	}
+	var input interface{}
+	if err := json.Unmarshal([]byte(body), &input); err != nil {
+		return body
+	}
+	sensitive := (*bodyType).SelectValues(input, types.SensitiveSelector)
+	if sensitive == nil {
+		return body
+	}
+	output, err := json.Marshal(utils.RedactObject(input, sensitive))
+	if err != nil {
+		return body
+	}
+	return string(output)
+}
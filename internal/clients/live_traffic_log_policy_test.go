@@ -0,0 +1,68 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+func TestRedactBody(t *testing.T) {
+	bodyType := (&types.ObjectType{
+		Properties: map[string]types.ObjectProperty{
+			"name": {
+				Type: &types.TypeReference{Type: (&types.StringType{}).AsTypeBase()},
+			},
+			"properties": {
+				Type: &types.TypeReference{Type: (&types.ObjectType{
+					Properties: map[string]types.ObjectProperty{
+						"administratorLogin": {
+							Type: &types.TypeReference{Type: (&types.StringType{}).AsTypeBase()},
+						},
+						"administratorLoginPassword": {
+							Type: &types.TypeReference{Type: (&types.StringType{Sensitive: true}).AsTypeBase()},
+						},
+					},
+				}).AsTypeBase()},
+			},
+		},
+	}).AsTypeBase()
+
+	testcases := []struct {
+		name     string
+		body     string
+		bodyType *types.TypeBase
+		expected string
+	}{
+		{
+			name:     "redact sensitive values",
+			body:     `{"name":"myServer","properties":{"administratorLogin":"admin","administratorLoginPassword":"secret"}}`,
+			bodyType: bodyType,
+			expected: `{"name":"myServer","properties":{"administratorLogin":"admin","administratorLoginPassword":"REDACTED"}}`,
+		},
+		{
+			name:     "no sensitive values",
+			body:     `{"name":"myServer","properties":{"administratorLogin":"admin"}}`,
+			bodyType: bodyType,
+			expected: `{"name":"myServer","properties":{"administratorLogin":"admin"}}`,
+		},
+		{
+			name:     "unknown body type",
+			body:     `{"name":"myServer","properties":{"administratorLoginPassword":"secret"}}`,
+			bodyType: nil,
+			expected: `{"name":"myServer","properties":{"administratorLoginPassword":"secret"}}`,
+		},
+		{
+			name:     "invalid json",
+			body:     `invalid`,
+			bodyType: bodyType,
+			expected: `invalid`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Logf("[DEBUG] Testing %s", tc.name)
+		if actual := redactBody(tc.body, tc.bodyType); actual != tc.expected {
+			t.Fatalf("expected %s but got %s", tc.expected, actual)
+		}
+	}
+}
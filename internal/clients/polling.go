@@ -0,0 +1,35 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+)
+
+// pollingResult is the result of the long-running operations, the pollers require a named type to generate the resume tokens.
+type pollingResult interface{}
+
+// PollingInterruptedError is returned when the polling of a long-running operation stops before the operation is done,
+// for example, the context is canceled or the polling request fails. The operation is still running on the service side,
+// and its polling can be resumed by setting the ResumeToken in the RequestOptions.
+type PollingInterruptedError struct {
+	// ResumeToken represents the poller of the operation, it contains the `Azure-AsyncOperation` or `Location` polling URL.
+	ResumeToken string
+	Err         error
+}
+
+func (e *PollingInterruptedError) Error() string {
+	return fmt.Sprintf("polling the long-running operation: %+v", e.Err)
+}
+
+func (e *PollingInterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// ResumeTokenFromError returns the resume token of the long-running operation if the error is a PollingInterruptedError.
+func ResumeTokenFromError(err error) string {
+	var interruptedErr *PollingInterruptedError
+	if errors.As(err, &interruptedErr) {
+		return interruptedErr.ResumeToken
+	}
+	return ""
+}
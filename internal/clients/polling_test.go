@@ -0,0 +1,72 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func TestResourceClient_resumePolling(t *testing.T) {
+	var succeeded, puts atomic.Int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/operations/1":
+			if succeeded.Load() == 0 {
+				_, _ = w.Write([]byte(`{"status":"InProgress"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"Succeeded"}`))
+		case r.Method == http.MethodPut:
+			puts.Add(1)
+			w.Header().Set("Azure-AsyncOperation", server.URL+"/operations/1")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"properties":{"provisioningState":"Creating"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"properties":{"provisioningState":"Succeeded"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &ResourceClient{
+		host: server.URL,
+		pl:   runtime.NewPipeline("test", "v0.1.0", runtime.PipelineOptions{}, nil),
+	}
+	resourceID := "/subscriptions/000/resourceGroups/rg/providers/Microsoft.Test/tests/test"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	_, err := client.CreateOrUpdate(ctx, resourceID, "2023-01-01", map[string]interface{}{}, RequestOptions{})
+	cancel()
+	resumeToken := ResumeTokenFromError(err)
+	if resumeToken == "" {
+		t.Fatalf("expected a resume token, got error: %+v", err)
+	}
+
+	succeeded.Store(1)
+	out, err := client.CreateOrUpdate(context.Background(), resourceID, "2023-01-01", map[string]interface{}{}, RequestOptions{ResumeToken: resumeToken})
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+	if puts.Load() != 1 {
+		t.Fatalf("expected the PUT request to be sent once, got %d", puts.Load())
+	}
+	state := out.(map[string]interface{})["properties"].(map[string]interface{})["provisioningState"]
+	if state != "Succeeded" {
+		t.Fatalf("expected the final resource to be returned, got %+v", out)
+	}
+}
+
+func TestResumeTokenFromError(t *testing.T) {
+	if token := ResumeTokenFromError(context.Canceled); token != "" {
+		t.Fatalf("expected no resume token, got %q", token)
+	}
+	if token := ResumeTokenFromError(&PollingInterruptedError{ResumeToken: "token", Err: context.Canceled}); token != "token" {
+		t.Fatalf("expected the resume token, got %q", token)
+	}
+}
@@ -0,0 +1,73 @@
+package clients
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// RequestOptions contains the optional settings which are applied to a single operation.
+type RequestOptions struct {
+	Retry *RetryOptions
+	// Method overrides the default HTTP method of the operation.
+	Method string
+	// UrlPathSuffix is appended to the URL path of the operation, the request is sent to `{resourceId}/{UrlPathSuffix}`.
+	UrlPathSuffix string
+	// Collection sends the request to the collection of the resource, which is the resource ID without its name segment.
+	Collection bool
+	// QueryParameters are added to the URL query of the request, the `api-version` can't be overridden.
+	QueryParameters url.Values
+	// Headers are added to the request headers, they override the default headers like `Accept`.
+	Headers map[string]string
+	// ResumeToken resumes polling the long-running operation which was started by a previous request, the request isn't sent again.
+	// It's only supported by the create and update operations.
+	ResumeToken string
+}
+
+func (o RequestOptions) method(defaultMethod string) string {
+	if o.Method != "" {
+		return o.Method
+	}
+	return defaultMethod
+}
+
+func (o RequestOptions) urlPath(resourceID string) string {
+	if o.Collection {
+		if index := strings.LastIndex(resourceID, "/"); index > 0 {
+			resourceID = resourceID[:index]
+		}
+	}
+	if o.UrlPathSuffix != "" {
+		return fmt.Sprintf("%s/%s", resourceID, o.UrlPathSuffix)
+	}
+	return resourceID
+}
+
+func (o RequestOptions) query(apiVersion string) url.Values {
+	query := url.Values{}
+	for key, values := range o.QueryParameters {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	query.Set("api-version", apiVersion)
+	return query
+}
+
+// top returns the maximum number of items specified by the `$top` query parameter, it returns 0 if it's not specified.
+func (o RequestOptions) top() int {
+	top, err := strconv.Atoi(o.QueryParameters.Get("$top"))
+	if err != nil || top < 0 {
+		return 0
+	}
+	return top
+}
+
+func (o RequestOptions) setHeaders(req *policy.Request) {
+	for key, value := range o.Headers {
+		req.Raw().Header.Set(key, value)
+	}
+}
@@ -0,0 +1,101 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func TestRequestOptions(t *testing.T) {
+	resourceID := "/subscriptions/000/resourceGroups/rg/providers/Microsoft.Test/resources/name"
+
+	testcases := []struct {
+		name           string
+		options        RequestOptions
+		expectedMethod string
+		expectedPath   string
+		expectedQuery  string
+	}{
+		{
+			name:           "default options",
+			options:        RequestOptions{},
+			expectedMethod: http.MethodPut,
+			expectedPath:   resourceID,
+			expectedQuery:  "api-version=2023-01-01",
+		},
+		{
+			name: "custom method, url path suffix and query parameters",
+			options: RequestOptions{
+				Method:        http.MethodPost,
+				UrlPathSuffix: "create",
+				QueryParameters: url.Values{
+					"force":   []string{"true"},
+					"include": []string{"a", "b"},
+				},
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   resourceID + "/create",
+			expectedQuery:  "api-version=2023-01-01&force=true&include=a&include=b",
+		},
+		{
+			name: "collection",
+			options: RequestOptions{
+				Method:     http.MethodPost,
+				Collection: true,
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/subscriptions/000/resourceGroups/rg/providers/Microsoft.Test/resources",
+			expectedQuery:  "api-version=2023-01-01",
+		},
+		{
+			name: "api-version can't be overridden",
+			options: RequestOptions{
+				QueryParameters: url.Values{
+					"api-version": []string{"2020-01-01"},
+				},
+			},
+			expectedMethod: http.MethodPut,
+			expectedPath:   resourceID,
+			expectedQuery:  "api-version=2023-01-01",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Logf("[DEBUG] Testing %s", tc.name)
+		if actual := tc.options.method(http.MethodPut); actual != tc.expectedMethod {
+			t.Fatalf("expected method %s but got %s", tc.expectedMethod, actual)
+		}
+		if actual := tc.options.urlPath(resourceID); actual != tc.expectedPath {
+			t.Fatalf("expected url path %s but got %s", tc.expectedPath, actual)
+		}
+		if actual := tc.options.query("2023-01-01").Encode(); actual != tc.expectedQuery {
+			t.Fatalf("expected query %s but got %s", tc.expectedQuery, actual)
+		}
+	}
+}
+
+func TestRequestOptions_setHeaders(t *testing.T) {
+	req, err := runtime.NewRequest(context.Background(), http.MethodGet, "https://management.azure.com/subscriptions/000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Raw().Header.Set("Accept", "application/json")
+
+	options := RequestOptions{
+		Headers: map[string]string{
+			"If-Match": "*",
+			"Accept":   "text/plain",
+		},
+	}
+	options.setHeaders(req)
+
+	if actual := req.Raw().Header.Get("If-Match"); actual != "*" {
+		t.Fatalf("expected header If-Match %s but got %s", "*", actual)
+	}
+	if actual := req.Raw().Header.Get("Accept"); actual != "text/plain" {
+		t.Fatalf("expected header Accept %s but got %s", "text/plain", actual)
+	}
+}
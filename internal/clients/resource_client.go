@@ -13,6 +13,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/terraform-provider-azapi/utils"
 )
 
 const (
@@ -43,22 +44,38 @@ func NewResourceClient(credential azcore.TokenCredential, opt *arm.ClientOptions
 	}, nil
 }
 
-func (client *ResourceClient) CreateOrUpdate(ctx context.Context, resourceID string, apiVersion string, body interface{}) (interface{}, error) {
-	resp, err := client.createOrUpdate(ctx, resourceID, apiVersion, body)
+func (client *ResourceClient) CreateOrUpdate(ctx context.Context, resourceID string, apiVersion string, body interface{}, options RequestOptions) (interface{}, error) {
+	return withRetry(ctx, options.Retry, func() (interface{}, error) {
+		return client.createOrUpdateThenPoll(ctx, http.MethodPut, resourceID, apiVersion, body, options)
+	})
+}
+
+// Patch updates the resource with a PATCH request, the body only needs to contain the properties which are changed.
+func (client *ResourceClient) Patch(ctx context.Context, resourceID string, apiVersion string, body interface{}, options RequestOptions) (interface{}, error) {
+	return withRetry(ctx, options.Retry, func() (interface{}, error) {
+		return client.createOrUpdateThenPoll(ctx, http.MethodPatch, resourceID, apiVersion, body, options)
+	})
+}
+
+func (client *ResourceClient) createOrUpdateThenPoll(ctx context.Context, method string, resourceID string, apiVersion string, body interface{}, options RequestOptions) (interface{}, error) {
+	if options.ResumeToken != "" {
+		return client.resumePolling(ctx, options.ResumeToken)
+	}
+	resp, err := client.createOrUpdate(ctx, method, resourceID, apiVersion, body, options)
 	if err != nil {
 		return nil, err
 	}
 	var responseBody interface{}
-	pt, err := runtime.NewPoller[interface{}](resp, client.pl, nil)
+	pt, err := runtime.NewPoller[pollingResult](resp, client.pl, nil)
 	if err == nil {
 		resp, err := pt.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
 			Frequency: 10 * time.Second,
 		})
 		if err == nil {
-			return resp, nil
+			return interface{}(resp), nil
 		}
 		if !client.shouldIgnorePollingError(err) {
-			return nil, err
+			return nil, pollingInterruptedError(pt, err)
 		}
 	}
 	if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
@@ -67,8 +84,35 @@ func (client *ResourceClient) CreateOrUpdate(ctx context.Context, resourceID str
 	return responseBody, nil
 }
 
-func (client *ResourceClient) createOrUpdate(ctx context.Context, resourceID string, apiVersion string, body interface{}) (*http.Response, error) {
-	req, err := client.createOrUpdateCreateRequest(ctx, resourceID, apiVersion, body)
+// resumePolling polls the long-running operation which is represented by the resume token until it's done.
+func (client *ResourceClient) resumePolling(ctx context.Context, resumeToken string) (interface{}, error) {
+	pt, err := runtime.NewPollerFromResumeToken[pollingResult](resumeToken, client.pl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("resuming the long-running operation: %+v", err)
+	}
+	resp, err := pt.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
+		Frequency: 10 * time.Second,
+	})
+	if err != nil {
+		return nil, pollingInterruptedError(pt, err)
+	}
+	return interface{}(resp), nil
+}
+
+// pollingInterruptedError wraps the polling error with the resume token of the poller, if the long-running operation is still in progress.
+func pollingInterruptedError(pt *runtime.Poller[pollingResult], err error) error {
+	if pt.Done() {
+		return err
+	}
+	resumeToken, tokenErr := pt.ResumeToken()
+	if tokenErr != nil {
+		return err
+	}
+	return &PollingInterruptedError{ResumeToken: resumeToken, Err: err}
+}
+
+func (client *ResourceClient) createOrUpdate(ctx context.Context, method string, resourceID string, apiVersion string, body interface{}, options RequestOptions) (*http.Response, error) {
+	req, err := client.createOrUpdateCreateRequest(ctx, method, resourceID, apiVersion, body, options)
 	if err != nil {
 		return nil, err
 	}
@@ -76,60 +120,75 @@ func (client *ResourceClient) createOrUpdate(ctx context.Context, resourceID str
 	if err != nil {
 		return nil, err
 	}
-	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated, http.StatusAccepted) {
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent) {
 		return nil, runtime.NewResponseError(resp)
 	}
 	return resp, nil
 }
 
-func (client *ResourceClient) createOrUpdateCreateRequest(ctx context.Context, resourceID string, apiVersion string, body interface{}) (*policy.Request, error) {
-	urlPath := resourceID
-	req, err := runtime.NewRequest(ctx, http.MethodPut, runtime.JoinPaths(client.host, urlPath))
+func (client *ResourceClient) createOrUpdateCreateRequest(ctx context.Context, method string, resourceID string, apiVersion string, body interface{}, options RequestOptions) (*policy.Request, error) {
+	urlPath := options.urlPath(resourceID)
+	req, err := runtime.NewRequest(ctx, options.method(method), runtime.JoinPaths(client.host, urlPath))
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", apiVersion)
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().URL.RawQuery = options.query(apiVersion).Encode()
 	req.Raw().Header.Set("Accept", "application/json")
+	options.setHeaders(req)
 	return req, runtime.MarshalAsJSON(req, body)
 }
 
-func (client *ResourceClient) Get(ctx context.Context, resourceID string, apiVersion string) (interface{}, error) {
-	req, err := client.getCreateRequest(ctx, resourceID, apiVersion)
+func (client *ResourceClient) Get(ctx context.Context, resourceID string, apiVersion string, options RequestOptions) (interface{}, error) {
+	responseBody, _, err := client.GetWithETag(ctx, resourceID, apiVersion, options)
+	return responseBody, err
+}
+
+// GetWithETag retrieves the resource and its ETag, the ETag is read from the `ETag` response header,
+// or the `etag` property in the response body if the header is not returned.
+func (client *ResourceClient) GetWithETag(ctx context.Context, resourceID string, apiVersion string, options RequestOptions) (interface{}, string, error) {
+	req, err := client.getCreateRequest(ctx, resourceID, apiVersion, options)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	resp, err := client.pl.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	if !runtime.HasStatusCode(resp, http.StatusOK) {
-		return nil, runtime.NewResponseError(resp)
+		return nil, "", runtime.NewResponseError(resp)
 	}
 
 	var responseBody interface{}
 	if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return responseBody, nil
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		etag = utils.GetETag(responseBody)
+	}
+	return responseBody, etag, nil
 }
 
-func (client *ResourceClient) getCreateRequest(ctx context.Context, resourceID string, apiVersion string) (*policy.Request, error) {
-	urlPath := resourceID
-	req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.host, urlPath))
+func (client *ResourceClient) getCreateRequest(ctx context.Context, resourceID string, apiVersion string, options RequestOptions) (*policy.Request, error) {
+	urlPath := options.urlPath(resourceID)
+	req, err := runtime.NewRequest(ctx, options.method(http.MethodGet), runtime.JoinPaths(client.host, urlPath))
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", apiVersion)
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().URL.RawQuery = options.query(apiVersion).Encode()
 	req.Raw().Header.Set("Accept", "application/json")
+	options.setHeaders(req)
 	return req, nil
 }
 
-func (client *ResourceClient) Delete(ctx context.Context, resourceID string, apiVersion string) (interface{}, error) {
-	resp, err := client.delete(ctx, resourceID, apiVersion)
+func (client *ResourceClient) Delete(ctx context.Context, resourceID string, apiVersion string, options RequestOptions) (interface{}, error) {
+	return withRetry(ctx, options.Retry, func() (interface{}, error) {
+		return client.deleteThenPoll(ctx, resourceID, apiVersion, options)
+	})
+}
+
+func (client *ResourceClient) deleteThenPoll(ctx context.Context, resourceID string, apiVersion string, options RequestOptions) (interface{}, error) {
+	resp, err := client.delete(ctx, resourceID, apiVersion, options)
 	if err != nil {
 		return nil, err
 	}
@@ -152,8 +211,8 @@ func (client *ResourceClient) Delete(ctx context.Context, resourceID string, api
 	return responseBody, nil
 }
 
-func (client *ResourceClient) delete(ctx context.Context, resourceID string, apiVersion string) (*http.Response, error) {
-	req, err := client.deleteCreateRequest(ctx, resourceID, apiVersion)
+func (client *ResourceClient) delete(ctx context.Context, resourceID string, apiVersion string, options RequestOptions) (*http.Response, error) {
+	req, err := client.deleteCreateRequest(ctx, resourceID, apiVersion, options)
 	if err != nil {
 		return nil, err
 	}
@@ -167,21 +226,26 @@ func (client *ResourceClient) delete(ctx context.Context, resourceID string, api
 	return resp, nil
 }
 
-func (client *ResourceClient) deleteCreateRequest(ctx context.Context, resourceID string, apiVersion string) (*policy.Request, error) {
-	urlPath := resourceID
-	req, err := runtime.NewRequest(ctx, http.MethodDelete, runtime.JoinPaths(client.host, urlPath))
+func (client *ResourceClient) deleteCreateRequest(ctx context.Context, resourceID string, apiVersion string, options RequestOptions) (*policy.Request, error) {
+	urlPath := options.urlPath(resourceID)
+	req, err := runtime.NewRequest(ctx, options.method(http.MethodDelete), runtime.JoinPaths(client.host, urlPath))
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", apiVersion)
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().URL.RawQuery = options.query(apiVersion).Encode()
 	req.Raw().Header.Set("Accept", "application/json")
+	options.setHeaders(req)
 	return req, nil
 }
 
-func (client *ResourceClient) Action(ctx context.Context, resourceID string, action string, apiVersion string, method string, body interface{}) (interface{}, error) {
-	resp, err := client.action(ctx, resourceID, action, apiVersion, method, body)
+func (client *ResourceClient) Action(ctx context.Context, resourceID string, action string, apiVersion string, method string, body interface{}, options RequestOptions) (interface{}, error) {
+	return withRetry(ctx, options.Retry, func() (interface{}, error) {
+		return client.actionThenPoll(ctx, resourceID, action, apiVersion, method, body, options)
+	})
+}
+
+func (client *ResourceClient) actionThenPoll(ctx context.Context, resourceID string, action string, apiVersion string, method string, body interface{}, options RequestOptions) (interface{}, error) {
+	resp, err := client.action(ctx, resourceID, action, apiVersion, method, body, options)
 	if err != nil {
 		return nil, err
 	}
@@ -216,8 +280,8 @@ func (client *ResourceClient) Action(ctx context.Context, resourceID string, act
 	return responseBody, nil
 }
 
-func (client *ResourceClient) action(ctx context.Context, resourceID string, action string, apiVersion string, method string, body interface{}) (*http.Response, error) {
-	req, err := client.actionCreateRequest(ctx, resourceID, action, apiVersion, method, body)
+func (client *ResourceClient) action(ctx context.Context, resourceID string, action string, apiVersion string, method string, body interface{}, options RequestOptions) (*http.Response, error) {
+	req, err := client.actionCreateRequest(ctx, resourceID, action, apiVersion, method, body, options)
 	if err != nil {
 		return nil, err
 	}
@@ -231,7 +295,7 @@ func (client *ResourceClient) action(ctx context.Context, resourceID string, act
 	return resp, nil
 }
 
-func (client *ResourceClient) actionCreateRequest(ctx context.Context, resourceID string, action string, apiVersion string, method string, body interface{}) (*policy.Request, error) {
+func (client *ResourceClient) actionCreateRequest(ctx context.Context, resourceID string, action string, apiVersion string, method string, body interface{}, options RequestOptions) (*policy.Request, error) {
 	urlPath := resourceID
 	if action != "" {
 		urlPath = fmt.Sprintf("%s/%s", resourceID, action)
@@ -240,17 +304,16 @@ func (client *ResourceClient) actionCreateRequest(ctx context.Context, resourceI
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", apiVersion)
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().URL.RawQuery = options.query(apiVersion).Encode()
 	req.Raw().Header.Set("Accept", "application/json")
+	options.setHeaders(req)
 	if method != "GET" && body != nil {
 		err = runtime.MarshalAsJSON(req, body)
 	}
 	return req, err
 }
 
-func (client *ResourceClient) List(ctx context.Context, url string, apiVersion string) (interface{}, error) {
+func (client *ResourceClient) List(ctx context.Context, url string, apiVersion string, options RequestOptions) (interface{}, error) {
 	pager := runtime.NewPager[interface{}](runtime.PagingHandler[interface{}]{
 		More: func(current interface{}) bool {
 			if current == nil {
@@ -275,9 +338,7 @@ func (client *ResourceClient) List(ctx context.Context, url string, apiVersion s
 				if err != nil {
 					return nil, err
 				}
-				reqQP := req.Raw().URL.Query()
-				reqQP.Set("api-version", apiVersion)
-				req.Raw().URL.RawQuery = reqQP.Encode()
+				req.Raw().URL.RawQuery = options.query(apiVersion).Encode()
 				request = req
 			} else {
 				nextLink := ""
@@ -291,6 +352,7 @@ func (client *ResourceClient) List(ctx context.Context, url string, apiVersion s
 				request = req
 			}
 			request.Raw().Header.Set("Accept", "application/json")
+			options.setHeaders(request)
 			resp, err := client.pl.Do(request)
 			if err != nil {
 				return nil, err
@@ -306,6 +368,8 @@ func (client *ResourceClient) List(ctx context.Context, url string, apiVersion s
 		},
 	})
 
+	// some APIs treat the `$top` as the page size and still return the `nextLink`, stop paging once the `$top` items are collected
+	top := options.top()
 	value := make([]interface{}, 0)
 	for pager.More() {
 		page, err := pager.NextPage(ctx)
@@ -317,6 +381,10 @@ func (client *ResourceClient) List(ctx context.Context, url string, apiVersion s
 			if pageMap["value"] != nil {
 				if pageValue, ok := pageMap["value"].([]interface{}); ok {
 					value = append(value, pageValue...)
+					if top > 0 && len(value) >= top {
+						value = value[:top]
+						break
+					}
 					continue
 				}
 			}
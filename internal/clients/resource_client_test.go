@@ -0,0 +1,45 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func TestResourceClient_listTop(t *testing.T) {
+	var pages atomic.Int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// each page contains 2 items and links to the next page, the `$top` is treated as the page size
+		page := pages.Add(1)
+		_, _ = fmt.Fprintf(w, `{"value":[{"name":"item%[1]d-1"},{"name":"item%[1]d-2"}],"nextLink":"%[2]s/items?page=%[3]d"}`, page, server.URL, page+1)
+	}))
+	defer server.Close()
+
+	client := &ResourceClient{
+		host: server.URL,
+		pl:   runtime.NewPipeline("test", "v0.1.0", runtime.PipelineOptions{}, nil),
+	}
+
+	out, err := client.List(context.Background(), "/items", "2023-01-01", RequestOptions{QueryParameters: url.Values{"$top": []string{"3"}}})
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+	if pages.Load() != 2 {
+		t.Fatalf("expected 2 pages to be requested, got %d", pages.Load())
+	}
+	value := out.(map[string]interface{})["value"].([]interface{})
+	if len(value) != 3 {
+		t.Fatalf("expected 3 items, got %+v", value)
+	}
+	if name := value[2].(map[string]interface{})["name"]; name != "item2-1" {
+		t.Fatalf("expected the first item of the second page to be the last item, got %v", name)
+	}
+}
@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+const (
+	defaultRetryInterval   = 10 * time.Second
+	defaultRetryMultiplier = 1.5
+)
+
+// RetryOptions configures how an operation is retried when it fails with an error that is known to be transient.
+type RetryOptions struct {
+	// ErrorMessageRegex is a list of regular expressions, the operation is retried if the error message matches any of them.
+	ErrorMessageRegex []*regexp.Regexp
+	// StatusCodes is a list of HTTP status codes, the operation is retried if the response has any of them.
+	StatusCodes []int
+	// Interval is the delay before the first retry. Defaults to 10 seconds.
+	Interval time.Duration
+	// Multiplier is the factor used to increase the interval after each retry. Defaults to 1.5.
+	Multiplier float64
+	// MaxElapsedTime is the maximum time spent on retries. If it's zero, the operation is retried until the context is done.
+	MaxElapsedTime time.Duration
+}
+
+func (o *RetryOptions) shouldRetry(err error) bool {
+	if o == nil || err == nil {
+		return false
+	}
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) {
+		for _, statusCode := range o.StatusCodes {
+			if responseErr.StatusCode == statusCode {
+				return true
+			}
+		}
+	}
+	for _, regex := range o.ErrorMessageRegex {
+		if regex != nil && regex.MatchString(err.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetry runs the operation, and runs it again with an exponential backoff if it fails with a retryable error.
+func withRetry(ctx context.Context, options *RetryOptions, operation func() (interface{}, error)) (interface{}, error) {
+	if options == nil {
+		return operation()
+	}
+
+	interval := options.Interval
+	if interval <= 0 {
+		interval = defaultRetryInterval
+	}
+	multiplier := options.Multiplier
+	if multiplier < 1 {
+		multiplier = defaultRetryMultiplier
+	}
+
+	start := time.Now()
+	for {
+		result, err := operation()
+		if !options.shouldRetry(err) {
+			return result, err
+		}
+		if options.MaxElapsedTime > 0 && time.Since(start)+interval > options.MaxElapsedTime {
+			return result, err
+		}
+
+		log.Printf("[INFO] retrying in %s, the operation failed with a retryable error: %+v", interval, err)
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(interval):
+		}
+		interval = time.Duration(float64(interval) * multiplier)
+	}
+}
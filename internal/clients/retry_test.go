@@ -0,0 +1,92 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+func TestWithRetry(t *testing.T) {
+	transientErr := errors.New("AnotherOperationInProgress: another operation is in progress")
+	conflictErr := &azcore.ResponseError{StatusCode: http.StatusConflict}
+	permanentErr := errors.New("InvalidParameter")
+
+	testcases := []struct {
+		name          string
+		options       *RetryOptions
+		errs          []error
+		expectedCalls int
+		expectError   bool
+	}{
+		{
+			name:          "no retry options",
+			options:       nil,
+			errs:          []error{transientErr, nil},
+			expectedCalls: 1,
+			expectError:   true,
+		},
+		{
+			name: "retry on error message",
+			options: &RetryOptions{
+				ErrorMessageRegex: []*regexp.Regexp{regexp.MustCompile("AnotherOperationInProgress")},
+				Interval:          time.Millisecond,
+			},
+			errs:          []error{transientErr, transientErr, nil},
+			expectedCalls: 3,
+			expectError:   false,
+		},
+		{
+			name: "retry on status code",
+			options: &RetryOptions{
+				StatusCodes: []int{http.StatusConflict},
+				Interval:    time.Millisecond,
+			},
+			errs:          []error{conflictErr, nil},
+			expectedCalls: 2,
+			expectError:   false,
+		},
+		{
+			name: "not retry on other errors",
+			options: &RetryOptions{
+				ErrorMessageRegex: []*regexp.Regexp{regexp.MustCompile("AnotherOperationInProgress")},
+				StatusCodes:       []int{http.StatusConflict},
+				Interval:          time.Millisecond,
+			},
+			errs:          []error{permanentErr, nil},
+			expectedCalls: 1,
+			expectError:   true,
+		},
+		{
+			name: "stop retrying after max elapsed time",
+			options: &RetryOptions{
+				ErrorMessageRegex: []*regexp.Regexp{regexp.MustCompile("AnotherOperationInProgress")},
+				Interval:          20 * time.Millisecond,
+				MaxElapsedTime:    30 * time.Millisecond,
+			},
+			errs:          []error{transientErr, transientErr, transientErr, nil},
+			expectedCalls: 2,
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Logf("[DEBUG] Testing %s", tc.name)
+		calls := 0
+		_, err := withRetry(context.Background(), tc.options, func() (interface{}, error) {
+			err := tc.errs[calls]
+			calls++
+			return nil, err
+		})
+		if calls != tc.expectedCalls {
+			t.Fatalf("expected %d calls but got %d", tc.expectedCalls, calls)
+		}
+		if tc.expectError != (err != nil) {
+			t.Fatalf("expected error %v but got %v", tc.expectError, err)
+		}
+	}
+}
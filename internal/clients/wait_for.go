@@ -0,0 +1,110 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/utils"
+)
+
+const defaultWaitForInterval = 10 * time.Second
+
+// WaitForOptions configures the condition which is waited for after a resource is created or updated.
+type WaitForOptions struct {
+	// Path is the path of the property in the response body, it supports the same expressions as the `response_export_values`.
+	Path string
+	// Values is a list of expected values, the condition holds when all the values at the path equal to any of them, ignoring casing.
+	Values []string
+	// Interval is the delay between two GET requests. Defaults to 10 seconds.
+	Interval time.Duration
+	// Timeout is the maximum time spent on waiting. If it's zero, it waits until the context is done.
+	Timeout time.Duration
+	// BeforeDelete specifies whether the condition is also waited for before the resource is deleted.
+	BeforeDelete bool
+}
+
+// Holds returns whether the values at the path in the response body are all expected values, it also returns the actual values.
+func (o *WaitForOptions) Holds(responseBody interface{}) (bool, []string) {
+	actual := leafValues(utils.ExtractObject(responseBody, o.Path))
+	if len(actual) == 0 {
+		return false, actual
+	}
+	for _, value := range actual {
+		matched := false
+		for _, expected := range o.Values {
+			if strings.EqualFold(value, expected) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, actual
+		}
+	}
+	return true, actual
+}
+
+// WaitFor retrieves the resource until the condition holds, and returns the last response body.
+func (client *ResourceClient) WaitFor(ctx context.Context, resourceID string, apiVersion string, waitFor *WaitForOptions, options RequestOptions) (interface{}, error) {
+	if waitFor == nil {
+		return client.Get(ctx, resourceID, apiVersion, options)
+	}
+	if waitFor.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, waitFor.Timeout)
+		defer cancel()
+	}
+	interval := waitFor.Interval
+	if interval <= 0 {
+		interval = defaultWaitForInterval
+	}
+
+	for {
+		responseBody, err := client.Get(ctx, resourceID, apiVersion, options)
+		if err != nil {
+			return nil, err
+		}
+		holds, actual := waitFor.Holds(responseBody)
+		if holds {
+			return responseBody, nil
+		}
+
+		log.Printf("[INFO] waiting for %q of %s to be one of %v, current values: %v", waitFor.Path, resourceID, waitFor.Values, actual)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for %q of %s to be one of %v, current values: %v: %+v", waitFor.Path, resourceID, waitFor.Values, actual, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// leafValues returns the string representations of the primitive values in the input, the values in maps are sorted by keys.
+func leafValues(input interface{}) []string {
+	switch v := input.(type) {
+	case nil:
+		return []string{}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		result := make([]string, 0)
+		for _, key := range keys {
+			result = append(result, leafValues(v[key])...)
+		}
+		return result
+	case []interface{}:
+		result := make([]string, 0)
+		for _, item := range v {
+			result = append(result, leafValues(item)...)
+		}
+		return result
+	default:
+		return []string{fmt.Sprintf("%v", v)}
+	}
+}
@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func TestWaitForOptions_Holds(t *testing.T) {
+	body := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"powerState": map[string]interface{}{
+				"code": "Running",
+			},
+			"connections": []interface{}{
+				map[string]interface{}{"status": "Approved"},
+				map[string]interface{}{"status": "Pending"},
+			},
+			"enabled": true,
+		},
+	}
+
+	testcases := []struct {
+		path     string
+		values   []string
+		expected bool
+	}{
+		{path: "properties.powerState.code", values: []string{"running"}, expected: true},
+		{path: "properties.powerState.code", values: []string{"Stopped"}, expected: false},
+		{path: "properties.enabled", values: []string{"true"}, expected: true},
+		{path: "properties.connections[*].status", values: []string{"Approved"}, expected: false},
+		{path: "properties.connections[*].status", values: []string{"Approved", "Pending"}, expected: true},
+		{path: "properties.connections[0].status", values: []string{"Approved"}, expected: true},
+		{path: "properties.missing", values: []string{""}, expected: false},
+	}
+
+	for _, tc := range testcases {
+		options := WaitForOptions{Path: tc.path, Values: tc.values}
+		if actual, _ := options.Holds(body); actual != tc.expected {
+			t.Errorf("path %q with values %v: expected %v, got %v", tc.path, tc.values, tc.expected, actual)
+		}
+	}
+}
+
+func TestResourceClient_WaitFor(t *testing.T) {
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if gets.Add(1) < 3 {
+			_, _ = w.Write([]byte(`{"properties":{"provisioningState":"Updating"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"properties":{"provisioningState":"Succeeded"}}`))
+	}))
+	defer server.Close()
+
+	client := &ResourceClient{
+		host: server.URL,
+		pl:   runtime.NewPipeline("test", "v0.1.0", runtime.PipelineOptions{}, nil),
+	}
+	resourceID := "/subscriptions/000/resourceGroups/rg/providers/Microsoft.Test/tests/test"
+	waitFor := &WaitForOptions{
+		Path:     "properties.provisioningState",
+		Values:   []string{"Succeeded"},
+		Interval: time.Millisecond,
+	}
+
+	if _, err := client.WaitFor(context.Background(), resourceID, "2023-01-01", waitFor, RequestOptions{}); err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+	if gets.Load() != 3 {
+		t.Fatalf("expected 3 GET requests, got %d", gets.Load())
+	}
+
+	waitFor.Values = []string{"Failed"}
+	waitFor.Timeout = 50 * time.Millisecond
+	if _, err := client.WaitFor(context.Background(), resourceID, "2023-01-01", waitFor, RequestOptions{}); err == nil {
+		t.Fatalf("expected a timeout error, got nil")
+	}
+}
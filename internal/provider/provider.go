@@ -17,11 +17,13 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/features"
 	"github.com/Azure/terraform-provider-azapi/internal/services"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
 	"github.com/Azure/terraform-provider-azapi/version"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -34,6 +36,8 @@ func AzureProvider() provider.Provider {
 	return &Provider{}
 }
 
+var _ provider.ProviderWithFunctions = &Provider{}
+
 type Provider struct {
 }
 
@@ -66,6 +70,7 @@ type providerData struct {
 	DefaultNamingSuffix         types.String `tfsdk:"default_naming_suffix"`
 	DefaultLocation             types.String `tfsdk:"default_location"`
 	DefaultTags                 types.Map    `tfsdk:"default_tags"`
+	DataPlaneTypeDefinitions    types.String `tfsdk:"data_plane_type_definitions"`
 }
 
 func (model providerData) GetClientId() (*string, error) {
@@ -321,6 +326,11 @@ func (p Provider) Schema(ctx context.Context, request provider.SchemaRequest, re
 				},
 				Description: "The default tags which should be used for resources.",
 			},
+
+			"data_plane_type_definitions": schema.StringAttribute{
+				Optional:    true,
+				Description: "The file path or the inline JSON of the additional data plane resource type definitions, which are used by the `azapi_data_plane_resource` resource.",
+			},
 		},
 	}
 }
@@ -508,6 +518,11 @@ func (p Provider) Configure(ctx context.Context, request provider.ConfigureReque
 		}
 	}
 
+	if err := parse.LoadDataPlaneTypeDefinitions(model.DataPlaneTypeDefinitions.ValueString()); err != nil {
+		response.Diagnostics.AddError("Invalid `data_plane_type_definitions` value.", err.Error())
+		return
+	}
+
 	var cloudConfig cloud.Configuration
 	env := model.Environment.ValueString()
 	switch strings.ToLower(env) {
@@ -631,18 +646,30 @@ func (p Provider) DataSources(ctx context.Context) []func() datasource.DataSourc
 		func() datasource.DataSource {
 			return &services.ResourceListDataSource{}
 		},
+		func() datasource.DataSource {
+			return &services.ResourceDiscoveryDataSource{}
+		},
+		func() datasource.DataSource {
+			return &services.ResourceGraphQueryDataSource{}
+		},
 		func() datasource.DataSource {
 			return &services.ResourceActionDataSource{}
 		},
 		func() datasource.DataSource {
 			return &services.AzapiResourceDataSource{}
 		},
+		func() datasource.DataSource {
+			return &services.DataPlaneResourceDataSource{}
+		},
+		func() datasource.DataSource {
+			return &services.DataPlaneActionDataSource{}
+		},
 	}
 
 }
 
 func (p Provider) Resources(ctx context.Context) []func() resource.Resource {
-	return []func() resource.Resource{
+	resources := []func() resource.Resource{
 		func() resource.Resource {
 			return &services.AzapiResource{}
 		},
@@ -655,6 +682,36 @@ func (p Provider) Resources(ctx context.Context) []func() resource.Resource {
 		func() resource.Resource {
 			return &services.DataPlaneResource{}
 		},
+		func() resource.Resource {
+			return &services.DataPlaneActionResource{}
+		},
+	}
+	return append(resources, services.TypedResources()...)
+}
+
+func (p Provider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		func() function.Function {
+			return &services.BuildResourceIdFunction{}
+		},
+		func() function.Function {
+			return &services.ParseResourceIdFunction{}
+		},
+		func() function.Function {
+			return &services.TenantResourceIdFunction{}
+		},
+		func() function.Function {
+			return &services.SubscriptionResourceIdFunction{}
+		},
+		func() function.Function {
+			return &services.ManagementGroupResourceIdFunction{}
+		},
+		func() function.Function {
+			return &services.ResourceGroupResourceIdFunction{}
+		},
+		func() function.Function {
+			return &services.ExtensionResourceIdFunction{}
+		},
 	}
 }
 
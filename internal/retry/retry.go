@@ -0,0 +1,131 @@
+package retry
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type Model struct {
+	ErrorMessageRegex     types.List    `tfsdk:"error_message_regex"`
+	StatusCodes           types.List    `tfsdk:"status_codes"`
+	IntervalSeconds       types.Int64   `tfsdk:"interval_seconds"`
+	Multiplier            types.Float64 `tfsdk:"multiplier"`
+	MaxElapsedTimeSeconds types.Int64   `tfsdk:"max_elapsed_time_seconds"`
+}
+
+func (m Model) ModelType() attr.Type {
+	return types.ObjectType{AttrTypes: m.AttrType()}
+}
+
+func (m Model) AttrType() map[string]attr.Type {
+	return map[string]attr.Type{
+		"error_message_regex":      types.ListType{ElemType: types.StringType},
+		"status_codes":             types.ListType{ElemType: types.Int64Type},
+		"interval_seconds":         types.Int64Type,
+		"multiplier":               types.Float64Type,
+		"max_elapsed_time_seconds": types.Int64Type,
+	}
+}
+
+func Block() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Attributes: map[string]schema.Attribute{
+			"error_message_regex": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsValidRegex()),
+				},
+			},
+
+			"status_codes": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+				},
+			},
+
+			"interval_seconds": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"multiplier": schema.Float64Attribute{
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(1),
+				},
+			},
+
+			"max_elapsed_time_seconds": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// ExpandRetry converts the `retry` block into the retry options used by the clients, it returns nil if the block is not specified.
+func ExpandRetry(input types.Object) *clients.RetryOptions {
+	if input.IsNull() || input.IsUnknown() {
+		return nil
+	}
+
+	var model Model
+	if diags := input.As(context.Background(), &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		tflog.Warn(context.Background(), "failed to convert the retry block")
+		return nil
+	}
+
+	options := &clients.RetryOptions{
+		ErrorMessageRegex: make([]*regexp.Regexp, 0),
+		StatusCodes:       make([]int, 0),
+	}
+	for _, element := range model.ErrorMessageRegex.Elements() {
+		v, ok := element.(types.String)
+		if !ok || v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		regex, err := regexp.Compile(v.ValueString())
+		if err != nil {
+			tflog.Warn(context.Background(), "skipping invalid regular expression in the retry block: "+err.Error())
+			continue
+		}
+		options.ErrorMessageRegex = append(options.ErrorMessageRegex, regex)
+	}
+	for _, element := range model.StatusCodes.Elements() {
+		v, ok := element.(types.Int64)
+		if !ok || v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		options.StatusCodes = append(options.StatusCodes, int(v.ValueInt64()))
+	}
+	if v := model.IntervalSeconds.ValueInt64(); v > 0 {
+		options.Interval = time.Duration(v) * time.Second
+	}
+	if v := model.Multiplier.ValueFloat64(); v > 0 {
+		options.Multiplier = v
+	}
+	if v := model.MaxElapsedTimeSeconds.ValueInt64(); v > 0 {
+		options.MaxElapsedTime = time.Duration(v) * time.Second
+	}
+	return options
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/locks"
+	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/defaults"
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myplanmodifier"
@@ -17,6 +22,7 @@ import (
 	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -30,19 +36,29 @@ import (
 )
 
 type DataPlaneResourceModel struct {
-	ID                    types.String   `tfsdk:"id"`
-	Name                  types.String   `tfsdk:"name"`
-	ParentID              types.String   `tfsdk:"parent_id"`
-	Type                  types.String   `tfsdk:"type"`
-	Body                  types.String   `tfsdk:"body"`
-	Payload               types.Dynamic  `tfsdk:"payload"`
-	IgnoreCasing          types.Bool     `tfsdk:"ignore_casing"`
-	IgnoreMissingProperty types.Bool     `tfsdk:"ignore_missing_property"`
-	ResponseExportValues  types.List     `tfsdk:"response_export_values"`
-	Locks                 types.List     `tfsdk:"locks"`
-	Output                types.String   `tfsdk:"output"`
-	OutputPayload         types.Dynamic  `tfsdk:"output_payload"`
-	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+	ID                      types.String   `tfsdk:"id"`
+	Name                    types.String   `tfsdk:"name"`
+	ParentID                types.String   `tfsdk:"parent_id"`
+	Type                    types.String   `tfsdk:"type"`
+	Body                    types.String   `tfsdk:"body"`
+	Payload                 types.Dynamic  `tfsdk:"payload"`
+	IgnoreCasing            types.Bool     `tfsdk:"ignore_casing"`
+	IgnoreMissingProperty   types.Bool     `tfsdk:"ignore_missing_property"`
+	SchemaValidationEnabled types.Bool     `tfsdk:"schema_validation_enabled"`
+	ResponseExportValues    types.List     `tfsdk:"response_export_values"`
+	Locks                   types.List     `tfsdk:"locks"`
+	CreateHeaders           types.Map      `tfsdk:"create_headers"`
+	CreateQueryParameters   types.Map      `tfsdk:"create_query_parameters"`
+	ReadHeaders             types.Map      `tfsdk:"read_headers"`
+	ReadQueryParameters     types.Map      `tfsdk:"read_query_parameters"`
+	UpdateHeaders           types.Map      `tfsdk:"update_headers"`
+	UpdateQueryParameters   types.Map      `tfsdk:"update_query_parameters"`
+	DeleteHeaders           types.Map      `tfsdk:"delete_headers"`
+	DeleteQueryParameters   types.Map      `tfsdk:"delete_query_parameters"`
+	Output                  types.String   `tfsdk:"output"`
+	OutputPayload           types.Dynamic  `tfsdk:"output_payload"`
+	Retry                   types.Object   `tfsdk:"retry"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
 
 type DataPlaneResource struct {
@@ -53,6 +69,7 @@ var _ resource.Resource = &DataPlaneResource{}
 var _ resource.ResourceWithConfigure = &DataPlaneResource{}
 var _ resource.ResourceWithModifyPlan = &DataPlaneResource{}
 var _ resource.ResourceWithValidateConfig = &DataPlaneResource{}
+var _ resource.ResourceWithImportState = &DataPlaneResource{}
 
 func (r *DataPlaneResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
 	if v, ok := request.ProviderData.(*clients.Client); ok {
@@ -133,11 +150,17 @@ func (r *DataPlaneResource) Schema(ctx context.Context, request resource.SchemaR
 				Default:  defaults.BoolDefault(true),
 			},
 
+			"schema_validation_enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  defaults.BoolDefault(false),
+			},
+
 			"response_export_values": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Validators: []validator.List{
-					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsExtractPath()),
 				},
 			},
 
@@ -149,6 +172,22 @@ func (r *DataPlaneResource) Schema(ctx context.Context, request resource.SchemaR
 				},
 			},
 
+			"create_headers": headersAttribute(),
+
+			"create_query_parameters": queryParametersAttribute(),
+
+			"read_headers": headersAttribute(),
+
+			"read_query_parameters": queryParametersAttribute(),
+
+			"update_headers": headersAttribute(),
+
+			"update_query_parameters": queryParametersAttribute(),
+
+			"delete_headers": headersAttribute(),
+
+			"delete_query_parameters": queryParametersAttribute(),
+
 			"output": schema.StringAttribute{
 				Computed:           true,
 				DeprecationMessage: "This feature is deprecated and will be removed in a major release. Please use the `output_payload` argument to output the response of the resource.",
@@ -160,6 +199,8 @@ func (r *DataPlaneResource) Schema(ctx context.Context, request resource.SchemaR
 		},
 
 		Blocks: map[string]schema.Block{
+			"retry": retry.Block(),
+
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
 				Read:   true,
@@ -205,6 +246,36 @@ func (r *DataPlaneResource) ModifyPlan(ctx context.Context, request resource.Mod
 		plan.OutputPayload = basetypes.NewDynamicUnknown()
 		response.Diagnostics.Append(response.Plan.Set(ctx, plan)...)
 	}
+
+	if !plan.SchemaValidationEnabled.ValueBool() || config.Type.IsUnknown() || config.Body.IsUnknown() || config.Payload.IsUnknown() {
+		return
+	}
+
+	var body interface{}
+	switch {
+	case !config.Payload.IsNull():
+		out, err := expandPayload(config.Payload)
+		if err != nil {
+			return
+		}
+		body = out
+	case !config.Body.IsNull():
+		if err := json.Unmarshal([]byte(config.Body.ValueString()), &body); err != nil {
+			return
+		}
+	default:
+		body = map[string]interface{}{}
+	}
+
+	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(config.Type.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Invalid configuration", fmt.Sprintf(`The argument "type" is invalid: %s`, err.Error()))
+		return
+	}
+	if err := dataPlaneSchemaValidation(azureResourceType, apiVersion, body); err != nil {
+		response.Diagnostics.AddError("Invalid configuration", err.Error())
+		return
+	}
 }
 
 func (r *DataPlaneResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
@@ -237,8 +308,12 @@ func (r *DataPlaneResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan, s
 	}
 
 	client := r.ProviderData.DataPlaneClient
-	if isNewResource := state == nil || state.Raw.IsNull(); isNewResource {
-		_, err = client.Get(ctx, id)
+	isNewResource := state == nil || state.Raw.IsNull()
+	if isNewResource {
+		_, err = client.Get(ctx, id, clients.RequestOptions{
+			Headers:         expandHeaders(model.ReadHeaders),
+			QueryParameters: expandQueryParameters(model.ReadQueryParameters),
+		})
 		if err == nil {
 			diagnostics.AddError("Resource already exists", tf.ImportAsExistsError("azapi_data_plane_resource", id.ID()).Error())
 			return
@@ -274,7 +349,16 @@ func (r *DataPlaneResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan, s
 		defer locks.UnlockByID(id)
 	}
 
-	responseBody, err := client.CreateOrUpdateThenPoll(ctx, id, body)
+	options := clients.RequestOptions{
+		Retry:           retry.ExpandRetry(model.Retry),
+		Headers:         expandHeaders(model.UpdateHeaders),
+		QueryParameters: expandQueryParameters(model.UpdateQueryParameters),
+	}
+	if isNewResource {
+		options.Headers = expandHeaders(model.CreateHeaders)
+		options.QueryParameters = expandQueryParameters(model.CreateQueryParameters)
+	}
+	responseBody, err := client.CreateOrUpdateThenPoll(ctx, id, body, options)
 	if err != nil {
 		diagnostics.AddError("Failed to create/update resource", fmt.Errorf("creating/updating %q: %+v", id, err).Error())
 		return
@@ -309,7 +393,10 @@ func (r *DataPlaneResource) Read(ctx context.Context, request resource.ReadReque
 	}
 
 	client := r.ProviderData.DataPlaneClient
-	responseBody, err := client.Get(ctx, id)
+	responseBody, err := client.Get(ctx, id, clients.RequestOptions{
+		Headers:         expandHeaders(model.ReadHeaders),
+		QueryParameters: expandQueryParameters(model.ReadQueryParameters),
+	})
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			tflog.Info(ctx, fmt.Sprintf("[INFO] Error reading %q - removing from state", id.ID()))
@@ -407,8 +494,129 @@ func (r *DataPlaneResource) Delete(ctx context.Context, request resource.DeleteR
 		defer locks.UnlockByID(lockId)
 	}
 
-	_, err = client.DeleteThenPoll(ctx, id)
+	_, err = client.DeleteThenPoll(ctx, id, clients.RequestOptions{
+		Retry:           retry.ExpandRetry(model.Retry),
+		Headers:         expandHeaders(model.DeleteHeaders),
+		QueryParameters: expandQueryParameters(model.DeleteQueryParameters),
+	})
 	if err != nil && !utils.ResponseErrorWasNotFound(err) {
 		response.Diagnostics.AddError("Failed to delete resource", fmt.Errorf("deleting %s: %+v", id, err).Error())
 	}
 }
+
+func (r *DataPlaneResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	tflog.Debug(ctx, fmt.Sprintf("Importing Resource - parsing %q", request.ID))
+
+	input, rawQuery, _ := strings.Cut(request.ID, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid Resource ID", fmt.Errorf("parsing Resource ID %q: %+v", request.ID, err).Error())
+		return
+	}
+	resourceType := query.Get("type")
+	if resourceType == "" {
+		response.Diagnostics.AddError("Invalid Resource ID", fmt.Sprintf("the resource type must be specified as a query parameter, e.g. %s?type=Microsoft.AppConfiguration/configurationStores/keyValues@1.0", input))
+		return
+	}
+	if apiVersion := query.Get("api-version"); apiVersion != "" && !strings.Contains(resourceType, "@") {
+		resourceType = fmt.Sprintf("%s@%s", resourceType, apiVersion)
+	}
+	if !strings.Contains(resourceType, "@") {
+		apiVersions := azure.GetDataPlaneApiVersions(resourceType)
+		if len(apiVersions) == 0 {
+			response.Diagnostics.AddError("Invalid Resource ID", fmt.Sprintf("the api-version of resource type %q must be specified, e.g. %s@<api-version>", resourceType, resourceType))
+			return
+		}
+		resourceType = fmt.Sprintf("%s@%s", resourceType, apiVersions[len(apiVersions)-1])
+	}
+
+	id, err := parse.DataPlaneResourceIDWithResourceType(input, resourceType)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid Resource ID", fmt.Errorf("parsing Resource ID %q: %+v", input, err).Error())
+		return
+	}
+
+	client := r.ProviderData.DataPlaneClient
+
+	state := DataPlaneResourceModel{
+		ID:                      types.StringValue(id.ID()),
+		Name:                    types.StringValue(id.Name),
+		ParentID:                types.StringValue(id.ParentId),
+		Type:                    types.StringValue(fmt.Sprintf("%s@%s", id.AzureResourceType, id.ApiVersion)),
+		Body:                    types.StringValue("{}"),
+		IgnoreCasing:            types.BoolValue(false),
+		IgnoreMissingProperty:   types.BoolValue(true),
+		SchemaValidationEnabled: types.BoolValue(false),
+		ResponseExportValues:    types.ListNull(types.StringType),
+		Locks:                   types.ListNull(types.StringType),
+		CreateQueryParameters:   types.MapNull(types.ListType{ElemType: types.StringType}),
+		CreateHeaders:           types.MapNull(types.StringType),
+		ReadQueryParameters:     types.MapNull(types.ListType{ElemType: types.StringType}),
+		ReadHeaders:             types.MapNull(types.StringType),
+		UpdateQueryParameters:   types.MapNull(types.ListType{ElemType: types.StringType}),
+		UpdateHeaders:           types.MapNull(types.StringType),
+		DeleteQueryParameters:   types.MapNull(types.ListType{ElemType: types.StringType}),
+		DeleteHeaders:           types.MapNull(types.StringType),
+		Output:                  types.StringValue("{}"),
+		OutputPayload:           types.DynamicNull(),
+		Retry:                   types.ObjectNull(retry.Model{}.AttrType()),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"read":   types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	}
+
+	responseBody, err := client.Get(ctx, id, clients.RequestOptions{})
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("[INFO] Error reading %q - removing from state", id.ID()))
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("reading %s: %+v", id, err).Error())
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("resource %q is imported", id.ID()))
+	var body interface{}
+	if resourceDef, err := azure.GetDataPlaneResourceDefinition(id.AzureResourceType, id.ApiVersion); err == nil && resourceDef != nil {
+		body = resourceDef.GetWriteOnly(utils.NormalizeObject(responseBody))
+	} else {
+		body = removeDataPlaneReadOnlyFields(responseBody)
+		response.Diagnostics.AddWarning("Resource definition not found", fmt.Sprintf("The definition of %s@%s isn't embedded, only the well-known read-only fields %v are removed from the imported payload. Please remove the other read-only fields from the payload in the configuration.", id.AzureResourceType, id.ApiVersion, dataPlaneReadOnlyFields))
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid body", err.Error())
+		return
+	}
+	payload, err := dynamic.FromJSONImplied(data)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid payload", err.Error())
+		return
+	}
+	state.Payload = payload
+
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
+}
+
+// dataPlaneReadOnlyFields are the fields which are commonly returned by the data plane services but can't be set in the
+// request body, they're removed from the imported payload when the resource type isn't defined in the embedded schema.
+var dataPlaneReadOnlyFields = []string{"id", "etag", "@odata.context", "@odata.etag"}
+
+func removeDataPlaneReadOnlyFields(body interface{}) interface{} {
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok {
+		return body
+	}
+	out := make(map[string]interface{})
+	for key, value := range bodyMap {
+		if !slices.Contains(dataPlaneReadOnlyFields, key) {
+			out[key] = value
+		}
+	}
+	return out
+}
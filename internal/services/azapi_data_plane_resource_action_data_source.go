@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+type DataPlaneActionDataSourceModel struct {
+	ID                   types.String   `tfsdk:"id"`
+	ResourceID           types.String   `tfsdk:"resource_id"`
+	Type                 types.String   `tfsdk:"type"`
+	Action               types.String   `tfsdk:"action"`
+	Method               types.String   `tfsdk:"method"`
+	Payload              types.Dynamic  `tfsdk:"payload"`
+	Headers              types.Map      `tfsdk:"headers"`
+	QueryParameters      types.Map      `tfsdk:"query_parameters"`
+	ResponseExportValues types.List     `tfsdk:"response_export_values"`
+	OutputPayload        types.Dynamic  `tfsdk:"output_payload"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+}
+
+type DataPlaneActionDataSource struct {
+	ProviderData *clients.Client
+}
+
+var _ datasource.DataSource = &DataPlaneActionDataSource{}
+var _ datasource.DataSourceWithConfigure = &DataPlaneActionDataSource{}
+
+func (r *DataPlaneActionDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if v, ok := request.ProviderData.(*clients.Client); ok {
+		r.ProviderData = v
+	}
+}
+
+func (r *DataPlaneActionDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_data_plane_resource_action"
+}
+
+func (r *DataPlaneActionDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"type": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					myvalidator.StringIsResourceType(),
+				},
+			},
+
+			"resource_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					myvalidator.StringIsNotEmpty(),
+				},
+			},
+
+			"action": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"method": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("POST", "GET"),
+				},
+			},
+
+			"payload": schema.DynamicAttribute{
+				Optional: true,
+			},
+
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"query_parameters": schema.MapAttribute{
+				ElementType: types.ListType{ElemType: types.StringType},
+				Optional:    true,
+			},
+
+			"response_export_values": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsExtractPath()),
+				},
+			},
+
+			"output_payload": schema.DynamicAttribute{
+				Computed: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *DataPlaneActionDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var model DataPlaneActionDataSourceModel
+	if response.Diagnostics.Append(request.Config.Get(ctx, &model)...); response.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(model.Type.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Invalid configuration", fmt.Sprintf(`The argument "type" is invalid: %s`, err.Error()))
+		return
+	}
+
+	var requestBody interface{}
+	if !model.Payload.IsNull() {
+		out, err := expandPayload(model.Payload)
+		if err != nil {
+			response.Diagnostics.AddError("Invalid payload", err.Error())
+			return
+		}
+		requestBody = out
+	} else {
+		requestBody = map[string]interface{}{}
+	}
+
+	method := model.Method.ValueString()
+	if method == "" {
+		method = "POST"
+	}
+
+	id := parse.DataPlaneResourceId{
+		AzureResourceId:   model.ResourceID.ValueString(),
+		ApiVersion:        apiVersion,
+		AzureResourceType: azureResourceType,
+	}
+	client := r.ProviderData.DataPlaneClient
+	responseBody, err := client.Action(ctx, id, model.Action.ValueString(), method, requestBody, clients.RequestOptions{
+		Headers:         expandHeaders(model.Headers),
+		QueryParameters: expandQueryParameters(model.QueryParameters),
+	})
+	if err != nil {
+		response.Diagnostics.AddError("Failed to perform action", fmt.Errorf("performing action %s of %q: %+v", model.Action.ValueString(), id, err).Error())
+		return
+	}
+
+	model.ID = basetypes.NewStringValue(dataPlaneActionId(id.ID(), model.Action.ValueString()))
+	model.Method = basetypes.NewStringValue(method)
+	model.OutputPayload = types.DynamicValue(flattenOutputPayload(responseBody, AsStringList(model.ResponseExportValues)))
+
+	response.Diagnostics.Append(response.State.Set(ctx, &model)...)
+}
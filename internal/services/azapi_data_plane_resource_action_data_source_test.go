@@ -0,0 +1,43 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type DataPlaneActionDataSource struct{}
+
+func TestAccDataPlaneActionDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_data_plane_resource_action", "test")
+	r := DataPlaneActionDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output_payload.items.#").Exists(),
+			),
+		},
+	})
+}
+
+func (r DataPlaneActionDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azapi_data_plane_resource_action" "test" {
+  type        = azapi_data_plane_resource.test.type
+  resource_id = azapi_data_plane_resource.test.parent_id
+  action      = "revisions"
+  method      = "GET"
+  query_parameters = {
+    key = [azapi_data_plane_resource.test.name]
+  }
+  response_export_values = ["items"]
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/locks"
+	"github.com/Azure/terraform-provider-azapi/internal/retry"
+	"github.com/Azure/terraform-provider-azapi/internal/services/defaults"
+	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
+	"github.com/Azure/terraform-provider-azapi/internal/services/myplanmodifier"
+	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+type DataPlaneActionResourceModel struct {
+	ID                   types.String   `tfsdk:"id"`
+	Type                 types.String   `tfsdk:"type"`
+	ResourceId           types.String   `tfsdk:"resource_id"`
+	Action               types.String   `tfsdk:"action"`
+	Method               types.String   `tfsdk:"method"`
+	Payload              types.Dynamic  `tfsdk:"payload"`
+	When                 types.String   `tfsdk:"when"`
+	Locks                types.List     `tfsdk:"locks"`
+	Headers              types.Map      `tfsdk:"headers"`
+	QueryParameters      types.Map      `tfsdk:"query_parameters"`
+	ResponseExportValues types.List     `tfsdk:"response_export_values"`
+	OutputPayload        types.Dynamic  `tfsdk:"output_payload"`
+	Retry                types.Object   `tfsdk:"retry"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+}
+
+type DataPlaneActionResource struct {
+	ProviderData *clients.Client
+}
+
+var _ resource.Resource = &DataPlaneActionResource{}
+var _ resource.ResourceWithConfigure = &DataPlaneActionResource{}
+var _ resource.ResourceWithModifyPlan = &DataPlaneActionResource{}
+
+func (r *DataPlaneActionResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if v, ok := request.ProviderData.(*clients.Client); ok {
+		r.ProviderData = v
+	}
+}
+
+func (r *DataPlaneActionResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_data_plane_resource_action"
+}
+
+func (r *DataPlaneActionResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"type": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					myvalidator.StringIsResourceType(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"resource_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					myvalidator.StringIsNotEmpty(),
+				},
+			},
+
+			"action": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"method": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  defaults.StringDefault("POST"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("POST", "PATCH", "PUT", "DELETE", "GET", "HEAD"),
+				},
+			},
+
+			"payload": schema.DynamicAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.Dynamic{
+					myplanmodifier.DynamicUseStateWhen(dynamic.SemanticallyEqual),
+				},
+			},
+
+			"when": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  defaults.StringDefault("apply"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("apply", "destroy"),
+				},
+			},
+
+			"locks": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+				},
+			},
+
+			"headers": headersAttribute(),
+
+			"query_parameters": queryParametersAttribute(),
+
+			"response_export_values": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsExtractPath()),
+				},
+			},
+
+			"output_payload": schema.DynamicAttribute{
+				Computed: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"retry": retry.Block(),
+
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *DataPlaneActionResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	var config, plan, state *DataPlaneActionResourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// destroy doesn't need to modify plan
+	if config == nil {
+		return
+	}
+
+	if state == nil || !plan.ResponseExportValues.Equal(state.ResponseExportValues) || !plan.Payload.Equal(state.Payload) ||
+		!plan.Method.Equal(state.Method) || !plan.Headers.Equal(state.Headers) || !plan.QueryParameters.Equal(state.QueryParameters) {
+		plan.OutputPayload = basetypes.NewDynamicUnknown()
+		response.Diagnostics.Append(response.Plan.Set(ctx, plan)...)
+	}
+}
+
+func (r *DataPlaneActionResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var model DataPlaneActionResourceModel
+	if response.Diagnostics.Append(request.Plan.Get(ctx, &model)...); response.Diagnostics.HasError() {
+		return
+	}
+
+	if model.When.ValueString() == "apply" {
+		r.Action(ctx, model, &response.State, &response.Diagnostics)
+	} else {
+		if _, _, err := utils.GetAzureResourceTypeApiVersion(model.Type.ValueString()); err != nil {
+			response.Diagnostics.AddError("Invalid configuration", fmt.Sprintf(`The argument "type" is invalid: %s`, err.Error()))
+			return
+		}
+		model.ID = basetypes.NewStringValue(dataPlaneActionId(model.ResourceId.ValueString(), model.Action.ValueString()))
+		model.OutputPayload = basetypes.NewDynamicNull()
+		response.Diagnostics.Append(response.State.Set(ctx, model)...)
+	}
+}
+
+func (r *DataPlaneActionResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var model DataPlaneActionResourceModel
+	if response.Diagnostics.Append(request.Plan.Get(ctx, &model)...); response.Diagnostics.HasError() {
+		return
+	}
+
+	if model.When.ValueString() == "apply" {
+		r.Action(ctx, model, &response.State, &response.Diagnostics)
+	} else {
+		model.OutputPayload = basetypes.NewDynamicNull()
+		response.Diagnostics.Append(response.State.Set(ctx, model)...)
+	}
+}
+
+func (r *DataPlaneActionResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var model DataPlaneActionResourceModel
+	if response.Diagnostics.Append(request.State.Get(ctx, &model)...); response.Diagnostics.HasError() {
+		return
+	}
+
+	if model.When.ValueString() == "destroy" {
+		r.Action(ctx, model, &response.State, &response.Diagnostics)
+	}
+}
+
+func (r *DataPlaneActionResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+
+}
+
+func (r *DataPlaneActionResource) Action(ctx context.Context, model DataPlaneActionResourceModel, state *tfsdk.State, diagnostics *diag.Diagnostics) {
+	actionTimeout, diags := model.Timeouts.Create(ctx, 30*time.Minute)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, actionTimeout)
+	defer cancel()
+
+	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(model.Type.ValueString())
+	if err != nil {
+		diagnostics.AddError("Invalid configuration", fmt.Sprintf(`The argument "type" is invalid: %s`, err.Error()))
+		return
+	}
+
+	var requestBody interface{}
+	if !model.Payload.IsNull() {
+		out, err := expandPayload(model.Payload)
+		if err != nil {
+			diagnostics.AddError("Invalid payload", err.Error())
+			return
+		}
+		requestBody = out
+	} else {
+		requestBody = map[string]interface{}{}
+	}
+
+	for _, id := range AsStringList(model.Locks) {
+		locks.ByID(id)
+		defer locks.UnlockByID(id)
+	}
+
+	id := parse.DataPlaneResourceId{
+		AzureResourceId:   model.ResourceId.ValueString(),
+		ApiVersion:        apiVersion,
+		AzureResourceType: azureResourceType,
+	}
+	client := r.ProviderData.DataPlaneClient
+	responseBody, err := client.Action(ctx, id, model.Action.ValueString(), model.Method.ValueString(), requestBody, clients.RequestOptions{
+		Retry:           retry.ExpandRetry(model.Retry),
+		Headers:         expandHeaders(model.Headers),
+		QueryParameters: expandQueryParameters(model.QueryParameters),
+	})
+	if err != nil {
+		diagnostics.AddError("Failed to perform action", fmt.Errorf("performing action %s of %q: %+v", model.Action.ValueString(), id, err).Error())
+		return
+	}
+
+	model.ID = basetypes.NewStringValue(dataPlaneActionId(id.ID(), model.Action.ValueString()))
+	model.OutputPayload = types.DynamicValue(flattenOutputPayload(responseBody, AsStringList(model.ResponseExportValues)))
+
+	diagnostics.Append(state.Set(ctx, model)...)
+}
+
+func dataPlaneActionId(resourceId, action string) string {
+	if action == "" {
+		return resourceId
+	}
+	return fmt.Sprintf("%s/%s", resourceId, action)
+}
@@ -0,0 +1,71 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type DataPlaneActionResource struct{}
+
+func TestAccDataPlaneActionResource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_data_plane_resource_action", "test")
+	r := DataPlaneActionResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output_payload.locked").HasValue("true"),
+			),
+		},
+	})
+}
+
+func TestAccDataPlaneActionResource_basicWhenDestroy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_data_plane_resource_action", "test")
+	r := DataPlaneActionResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basicWhenDestroy(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").Exists(),
+			),
+		},
+		{
+			Destroy: true,
+			Config:  r.basicWhenDestroy(data),
+			Check:   resource.ComposeTestCheckFunc(),
+		},
+	})
+}
+
+func (r DataPlaneActionResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azapi_data_plane_resource_action" "test" {
+  type                   = azapi_data_plane_resource.test.type
+  resource_id            = "${azapi_data_plane_resource.test.parent_id}/locks/${azapi_data_plane_resource.test.name}"
+  method                 = "PUT"
+  response_export_values = ["locked"]
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
+
+func (r DataPlaneActionResource) basicWhenDestroy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azapi_data_plane_resource_action" "test" {
+  type        = azapi_data_plane_resource.test.type
+  resource_id = "${azapi_data_plane_resource.test.parent_id}/locks/${azapi_data_plane_resource.test.name}"
+  method      = "DELETE"
+  when        = "destroy"
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
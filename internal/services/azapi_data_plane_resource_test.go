@@ -3,11 +3,14 @@ package services_test
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
 	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
 	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -27,9 +30,62 @@ func TestAccDataPlaneResource_appConfigKeyValues(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
+		{
+			ResourceName:            data.ResourceName,
+			ImportState:             true,
+			ImportStateVerify:       true,
+			ImportStateIdFunc:       r.ImportIdFunc,
+			ImportStateVerifyIgnore: defaultIgnores(),
+			ImportStateCheck:        r.importedPayloadCheck,
+		},
 	})
 }
 
+func TestRemoveDataPlaneReadOnlyFields(t *testing.T) {
+	testData := []struct {
+		Input    interface{}
+		Expected interface{}
+	}{
+		{
+			Input: map[string]interface{}{
+				"id":             "https://myvault.vault.azure.net/secrets/mysecret",
+				"etag":           "W/\"1\"",
+				"@odata.context": "https://myservice/$metadata#items/$entity",
+				"@odata.etag":    "W/\"1\"",
+				"value":          "myvalue",
+				"properties": map[string]interface{}{
+					"id": "nested",
+				},
+			},
+			Expected: map[string]interface{}{
+				"value": "myvalue",
+				"properties": map[string]interface{}{
+					"id": "nested",
+				},
+			},
+		},
+		{
+			Input:    map[string]interface{}{},
+			Expected: map[string]interface{}{},
+		},
+		{
+			Input:    []interface{}{map[string]interface{}{"id": "item"}},
+			Expected: []interface{}{map[string]interface{}{"id": "item"}},
+		},
+		{
+			Input:    "value",
+			Expected: "value",
+		},
+	}
+
+	for _, v := range testData {
+		actual := services.RemoveDataPlaneReadOnlyFields(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expect %v but got %v", v.Expected, actual)
+		}
+	}
+}
+
 func TestAccDataPlaneResource_dynamicSchema(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azapi_data_plane_resource", "test")
 	r := DataPlaneResource{}
@@ -107,7 +163,7 @@ func (DataPlaneResource) Exists(ctx context.Context, client *clients.Client, sta
 		return nil, err
 	}
 
-	_, err = client.DataPlaneClient.Get(ctx, id)
+	_, err = client.DataPlaneClient.Get(ctx, id, clients.RequestOptions{})
 	if err == nil {
 		b := true
 		return &b, nil
@@ -119,6 +175,40 @@ func (DataPlaneResource) Exists(ctx context.Context, client *clients.Client, sta
 	return nil, fmt.Errorf("checking for presence of existing %s: %+v", id, err)
 }
 
+func (DataPlaneResource) ImportIdFunc(tfState *terraform.State) (string, error) {
+	state := tfState.RootModule().Resources["azapi_data_plane_resource.test"].Primary
+	return fmt.Sprintf("%s?type=%s", state.ID, state.Attributes["type"]), nil
+}
+
+func TestAccDataPlaneResource_invalidBody(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_data_plane_resource", "test")
+	r := DataPlaneResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.invalidBody(data),
+			ExpectError: regexp.MustCompile("embedded schema validation failed"),
+		},
+	})
+}
+
+// importedPayloadCheck checks the imported payload only contains the writable fields of the key value.
+func (r DataPlaneResource) importedPayloadCheck(states []*terraform.InstanceState) error {
+	if len(states) != 1 {
+		return fmt.Errorf("expect 1 imported state but got %d", len(states))
+	}
+	attributes := states[0].Attributes
+	if v := attributes["payload.value"]; v != "myvalue" {
+		return fmt.Errorf("expect `payload.value` to be %q but got %q", "myvalue", v)
+	}
+	for _, key := range []string{"payload.key", "payload.etag", "payload.last_modified", "payload.locked"} {
+		if v, ok := attributes[key]; ok {
+			return fmt.Errorf("expect read-only field `%s` to be removed from the imported payload but got %q", key, v)
+		}
+	}
+	return nil
+}
+
 func (r DataPlaneResource) appConfigKeyValues(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -397,3 +487,20 @@ resource "azapi_data_plane_resource" "test" {
 
 `, data.LocationPrimary, data.RandomString)
 }
+
+func (r DataPlaneResource) invalidBody(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azapi_data_plane_resource" "test" {
+  type      = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  parent_id = "acctest%[1]s.azconfig.io"
+  name      = "mykey"
+
+  schema_validation_enabled = true
+
+  payload = {
+    contentType = ""
+    value       = "myvalue"
+  }
+}
+`, data.RandomString)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
@@ -17,12 +18,14 @@ import (
 	aztypes "github.com/Azure/terraform-provider-azapi/internal/azure/types"
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/locks"
+	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/defaults"
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myplanmodifier"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
 	"github.com/Azure/terraform-provider-azapi/internal/tf"
+	"github.com/Azure/terraform-provider-azapi/internal/waitfor"
 	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
@@ -42,25 +45,52 @@ import (
 )
 
 type AzapiResourceModel struct {
-	ID                      types.String   `tfsdk:"id"`
-	Name                    types.String   `tfsdk:"name"`
-	ParentID                types.String   `tfsdk:"parent_id"`
-	Type                    types.String   `tfsdk:"type"`
-	Location                types.String   `tfsdk:"location"`
-	Identity                types.List     `tfsdk:"identity"`
-	Body                    types.String   `tfsdk:"body"`
-	Payload                 types.Dynamic  `tfsdk:"payload"`
-	Locks                   types.List     `tfsdk:"locks"`
-	RemovingSpecialChars    types.Bool     `tfsdk:"removing_special_chars"`
-	SchemaValidationEnabled types.Bool     `tfsdk:"schema_validation_enabled"`
-	IgnoreBodyChanges       types.List     `tfsdk:"ignore_body_changes"`
-	IgnoreCasing            types.Bool     `tfsdk:"ignore_casing"`
-	IgnoreMissingProperty   types.Bool     `tfsdk:"ignore_missing_property"`
-	ResponseExportValues    types.List     `tfsdk:"response_export_values"`
-	Output                  types.String   `tfsdk:"output"`
-	OutputPayload           types.Dynamic  `tfsdk:"output_payload"`
-	Tags                    types.Map      `tfsdk:"tags"`
-	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+	ID                            types.String   `tfsdk:"id"`
+	Name                          types.String   `tfsdk:"name"`
+	ParentID                      types.String   `tfsdk:"parent_id"`
+	Type                          types.String   `tfsdk:"type"`
+	Location                      types.String   `tfsdk:"location"`
+	Identity                      types.List     `tfsdk:"identity"`
+	Body                          types.String   `tfsdk:"body"`
+	Payload                       types.Dynamic  `tfsdk:"payload"`
+	SensitiveBody                 types.Dynamic  `tfsdk:"sensitive_body"`
+	CreateOnlyBody                types.Dynamic  `tfsdk:"create_only_body"`
+	Locks                         types.List     `tfsdk:"locks"`
+	RemovingSpecialChars          types.Bool     `tfsdk:"removing_special_chars"`
+	SchemaValidationEnabled       types.Bool     `tfsdk:"schema_validation_enabled"`
+	IgnoreBodyChanges             types.List     `tfsdk:"ignore_body_changes"`
+	IgnoreCasing                  types.Bool     `tfsdk:"ignore_casing"`
+	IgnoreMissingProperty         types.Bool     `tfsdk:"ignore_missing_property"`
+	CreateMethod                  types.String   `tfsdk:"create_method"`
+	CreateAction                  types.String   `tfsdk:"create_action"`
+	CreateOnCollection            types.Bool     `tfsdk:"create_on_collection"`
+	CreateQueryParameters         types.Map      `tfsdk:"create_query_parameters"`
+	CreateHeaders                 types.Map      `tfsdk:"create_headers"`
+	ReadMethod                    types.String   `tfsdk:"read_method"`
+	ReadAction                    types.String   `tfsdk:"read_action"`
+	ReadQueryParameters           types.Map      `tfsdk:"read_query_parameters"`
+	ReadHeaders                   types.Map      `tfsdk:"read_headers"`
+	UpdateMethod                  types.String   `tfsdk:"update_method"`
+	UpdateAction                  types.String   `tfsdk:"update_action"`
+	UpdateQueryParameters         types.Map      `tfsdk:"update_query_parameters"`
+	UpdateHeaders                 types.Map      `tfsdk:"update_headers"`
+	DeleteMethod                  types.String   `tfsdk:"delete_method"`
+	DeleteAction                  types.String   `tfsdk:"delete_action"`
+	DeleteQueryParameters         types.Map      `tfsdk:"delete_query_parameters"`
+	DeleteHeaders                 types.Map      `tfsdk:"delete_headers"`
+	ResponseExportValues          types.List     `tfsdk:"response_export_values"`
+	Output                        types.String   `tfsdk:"output"`
+	OutputPayload                 types.Dynamic  `tfsdk:"output_payload"`
+	SensitiveOutputEnabled        types.Bool     `tfsdk:"sensitive_output_enabled"`
+	SensitiveOutput               types.Dynamic  `tfsdk:"sensitive_output"`
+	IfMatchEnabled                types.Bool     `tfsdk:"if_match_enabled"`
+	ETag                          types.String   `tfsdk:"etag"`
+	ReplaceTriggersExternalValues types.Dynamic  `tfsdk:"replace_triggers_external_values"`
+	ReplaceTriggersRefs           types.List     `tfsdk:"replace_triggers_refs"`
+	Tags                          types.Map      `tfsdk:"tags"`
+	Retry                         types.Object   `tfsdk:"retry"`
+	WaitFor                       types.Object   `tfsdk:"wait_for"`
+	Timeouts                      timeouts.Value `tfsdk:"timeouts"`
 }
 
 var _ resource.Resource = &AzapiResource{}
@@ -143,6 +173,16 @@ func (r *AzapiResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 				},
 			},
 
+			"sensitive_body": schema.DynamicAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"create_only_body": schema.DynamicAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+
 			"body": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
@@ -180,11 +220,87 @@ func (r *AzapiResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 				Default:  defaults.BoolDefault(true),
 			},
 
+			"create_method": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodPut, http.MethodPost),
+				},
+			},
+
+			"create_action": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.StringIsNotEmpty(),
+				},
+			},
+
+			"create_on_collection": schema.BoolAttribute{
+				Optional: true,
+			},
+
+			"create_query_parameters": queryParametersAttribute(),
+
+			"create_headers": headersAttribute(),
+
+			"read_method": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodGet, http.MethodPost),
+				},
+			},
+
+			"read_action": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.StringIsNotEmpty(),
+				},
+			},
+
+			"read_query_parameters": queryParametersAttribute(),
+
+			"read_headers": headersAttribute(),
+
+			"update_method": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodPut, http.MethodPatch, http.MethodPost),
+				},
+			},
+
+			"update_action": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.StringIsNotEmpty(),
+				},
+			},
+
+			"update_query_parameters": queryParametersAttribute(),
+
+			"update_headers": headersAttribute(),
+
+			"delete_method": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodDelete, http.MethodPost),
+				},
+			},
+
+			"delete_action": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.StringIsNotEmpty(),
+				},
+			},
+
+			"delete_query_parameters": queryParametersAttribute(),
+
+			"delete_headers": headersAttribute(),
+
 			"response_export_values": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Validators: []validator.List{
-					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsExtractPath()),
 				},
 			},
 
@@ -211,6 +327,35 @@ func (r *AzapiResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 				Computed: true,
 			},
 
+			"sensitive_output_enabled": schema.BoolAttribute{
+				Optional: true,
+			},
+
+			"sensitive_output": schema.DynamicAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"if_match_enabled": schema.BoolAttribute{
+				Optional: true,
+			},
+
+			"etag": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"replace_triggers_external_values": schema.DynamicAttribute{
+				Optional: true,
+			},
+
+			"replace_triggers_refs": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsExtractPath()),
+				},
+			},
+
 			"tags": schema.MapAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
@@ -253,6 +398,10 @@ func (r *AzapiResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 				},
 			},
 
+			"retry": retry.Block(),
+
+			"wait_for": waitfor.Block(),
+
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
 				Read:   true,
@@ -322,6 +471,10 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 	}
 
 	defer func() {
+		// the create_only_body isn't sent to update the resource, changing it alone only updates the state
+		if state != nil && createOnlyBodyChangedOnly(*plan, *state) {
+			plan.ETag = state.ETag
+		}
 		response.Plan.Set(ctx, plan)
 	}()
 
@@ -330,6 +483,7 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 	if state != nil {
 		plan.Output = state.Output
 		plan.OutputPayload = state.OutputPayload
+		plan.SensitiveOutput = state.SensitiveOutput
 	}
 	resourceType := config.Type.ValueString()
 
@@ -352,6 +506,11 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 		return
 	}
 
+	// replace the resource if the external values are changed
+	if state != nil && externalValuesChanged(plan.ReplaceTriggersExternalValues, state.ReplaceTriggersExternalValues) {
+		response.RequiresReplace.Append(path.Root("replace_triggers_external_values"))
+	}
+
 	// if the config identity type and identity ids are not changed, use the state identity
 	if !config.Identity.IsNull() && state != nil && !state.Identity.IsNull() {
 		configIdentity := identity.FromList(config.Identity)
@@ -370,14 +529,31 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 		}
 		plan.Output = types.StringUnknown()
 		plan.OutputPayload = basetypes.NewDynamicUnknown()
+		plan.SensitiveOutput = basetypes.NewDynamicUnknown()
 		return
 	}
 
+	// the long-running operation which was interrupted in the last apply is resumed by an update
+	if state != nil {
+		resumeToken, diags := getPendingOperation(ctx, request.Private)
+		if response.Diagnostics.Append(diags...); response.Diagnostics.HasError() {
+			return
+		}
+		if resumeToken != "" {
+			plan.Output = types.StringUnknown()
+			plan.OutputPayload = basetypes.NewDynamicUnknown()
+			plan.SensitiveOutput = basetypes.NewDynamicUnknown()
+			response.Diagnostics.AddWarning("Pending operation", fmt.Sprintf("The last apply of %s was interrupted while the long-running operation was still in progress, it will be resumed.", state.ID.ValueString()))
+		}
+	}
+
 	if state == nil || !plan.Identity.Equal(state.Identity) || !plan.ResponseExportValues.Equal(state.ResponseExportValues) ||
+		!plan.SensitiveOutputEnabled.Equal(state.SensitiveOutputEnabled) ||
 		utils.NormalizeJson(plan.Body.ValueString()) != utils.NormalizeJson(state.Body.ValueString()) ||
-		!plan.Payload.Equal(state.Payload) {
+		!plan.Payload.Equal(state.Payload) || !plan.SensitiveBody.Equal(state.SensitiveBody) {
 		plan.Output = types.StringUnknown()
 		plan.OutputPayload = basetypes.NewDynamicUnknown()
+		plan.SensitiveOutput = basetypes.NewDynamicUnknown()
 	}
 
 	var body map[string]interface{}
@@ -411,6 +587,7 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 	if state == nil || !state.Tags.Equal(plan.Tags) {
 		plan.Output = types.StringUnknown()
 		plan.OutputPayload = basetypes.NewDynamicUnknown()
+		plan.SensitiveOutput = basetypes.NewDynamicUnknown()
 	}
 
 	// location field has a field level plan modifier which suppresses the diff if the location is not actually changed
@@ -427,28 +604,164 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 		// if the location is changed, replace the resource
 		response.RequiresReplace.Append(path.Root("location"))
 	}
+
+	// replace the resource if the deploy-time constant properties are changed, they can't be updated in place
+	if state != nil && resourceDef != nil {
+		changes, diags := deployTimeConstantChanges(body, *plan, *state, resourceDef)
+		if response.Diagnostics.Append(diags...); response.Diagnostics.HasError() {
+			return
+		}
+		if len(changes) != 0 {
+			if !config.Payload.IsNull() {
+				response.RequiresReplace.Append(path.Root("payload"))
+			} else {
+				response.RequiresReplace.Append(path.Root("body"))
+			}
+			response.Diagnostics.AddWarning("Resource replacement", fmt.Sprintf("The following properties of %s can't be changed after the resource is created, the resource will be replaced:\n%s", state.ID.ValueString(), strings.Join(changes, "\n")))
+		}
+	}
+
+	// replace the resource if the values at the paths in replace_triggers_refs are changed
+	if state != nil {
+		changes, diags := replaceTriggersRefsChanges(body, *plan, *state)
+		if response.Diagnostics.Append(diags...); response.Diagnostics.HasError() {
+			return
+		}
+		if len(changes) != 0 {
+			response.RequiresReplace.Append(path.Root("replace_triggers_refs"))
+			response.Diagnostics.AddWarning("Resource replacement", fmt.Sprintf("The values at the following paths in replace_triggers_refs of %s are changed, the resource will be replaced:\n%s", state.ID.ValueString(), strings.Join(changes, "\n")))
+		}
+	}
+
+	if response.Diagnostics.Append(expandBody(body, *plan)...); response.Diagnostics.HasError() {
+		return
+	}
+	body["name"] = plan.Name.ValueString()
+
 	if plan.SchemaValidationEnabled.ValueBool() {
-		if response.Diagnostics.Append(expandBody(body, *plan)...); response.Diagnostics.HasError() {
+		// the write-only properties are validated together with the body, but they're not compared with the existing resource
+		extraBody, err := writeOnlyBody(*plan, state == nil)
+		if err != nil {
+			response.Diagnostics.AddError("Invalid configuration", err.Error())
 			return
 		}
-		body["name"] = plan.Name.ValueString()
-		err = schemaValidation(azureResourceType, apiVersion, resourceDef, body)
+		err = schemaValidation(azureResourceType, apiVersion, resourceDef, utils.MergeObject(body, extraBody))
 		if err != nil {
 			response.Diagnostics.AddError("Invalid configuration", err.Error())
 			return
 		}
 	}
+
+	// show the properties which will be changed by the update, it only works for the resource types defined in the embedded schema
+	if state != nil && resourceDef != nil && plan.OutputPayload.IsUnknown() && len(response.RequiresReplace) == 0 {
+		changes, err := r.plannedChanges(ctx, body, *plan, *state, resourceDef)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("failed to compute the planned changes: %+v", err))
+		} else if len(changes) != 0 {
+			response.Diagnostics.AddWarning("Planned changes", fmt.Sprintf("The following properties of %s will be changed:\n%s", state.ID.ValueString(), strings.Join(changes, "\n")))
+		}
+	}
+}
+
+// plannedChanges retrieves the existing resource and compares its writable properties with the planned request body,
+// and returns the changes of the properties which are sent by the update, including the ones defaulted by the API which
+// are reset by a PUT request. The create_only_body isn't sent by the update, and the changes of the sensitive_body are
+// listed without their values, because they can't be read from the API.
+func (r *AzapiResource) plannedChanges(ctx context.Context, body map[string]interface{}, plan AzapiResourceModel, state AzapiResourceModel, resourceDef *aztypes.ResourceType) ([]string, error) {
+	id, err := parse.ResourceIDWithResourceType(state.ID.ValueString(), plan.Type.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	readTimeout, diags := plan.Timeouts.Read(ctx, 5*time.Minute)
+	if diags.HasError() {
+		return nil, fmt.Errorf("reading timeouts: %+v", diags)
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	existing, err := r.ProviderData.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion, plan.readRequestOptions())
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %+v", id, err)
+	}
+
+	var planned interface{} = body
+	if ignoreChanges := AsStringList(plan.IgnoreBodyChanges); len(ignoreChanges) != 0 {
+		if planned, err = overrideWithPaths(body, existing, ignoreChanges); err != nil {
+			return nil, err
+		}
+	}
+
+	before := (*resourceDef).GetWriteOnly(utils.NormalizeObject(existing))
+	after := (*resourceDef).GetWriteOnly(utils.NormalizeObject(planned))
+	beforeMap, beforeOk := before.(map[string]interface{})
+	afterMap, afterOk := after.(map[string]interface{})
+	if beforeOk && afterOk && beforeMap["location"] != nil && afterMap["location"] != nil {
+		beforeMap["location"] = location.Normalize(fmt.Sprintf("%v", beforeMap["location"]))
+		afterMap["location"] = location.Normalize(fmt.Sprintf("%v", afterMap["location"]))
+	}
+
+	// the PATCH request only contains the top level properties which are changed since the last apply, the others are left unchanged
+	if plan.UpdateMethod.ValueString() == http.MethodPatch && beforeOk && afterOk {
+		previous, diags := previousBody(state)
+		if diags.HasError() {
+			return nil, fmt.Errorf("building the request body of the last apply: %+v", diags)
+		}
+		previous["name"] = state.Name.ValueString()
+		patch, _ := utils.PatchObject((*resourceDef).GetWriteOnly(utils.NormalizeObject(previous)), afterMap).(map[string]interface{})
+		patched := make(map[string]interface{})
+		for key, value := range beforeMap {
+			patched[key] = value
+		}
+		for key, value := range patch {
+			if _, ok := beforeMap[key]; ok || value != nil {
+				patched[key] = value
+			}
+		}
+		after = patched
+	}
+
+	// the sensitive values are not shown in the changes
+	before = utils.RedactObject(before, (*resourceDef).GetSensitive(before))
+	after = utils.RedactObject(after, (*resourceDef).GetSensitive(after))
+	changes := utils.DiffObject(before, after)
+
+	previousSensitive, err := writeOnlyBody(state, false)
+	if err != nil {
+		return nil, err
+	}
+	plannedSensitive, err := writeOnlyBody(plan, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range utils.DiffObject(previousSensitive, plannedSensitive) {
+		changes = append(changes, fmt.Sprintf("%s: (sensitive value)", change[:strings.Index(change, ": ")]))
+	}
+	return changes, nil
 }
 
 func (r *AzapiResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
-	r.CreateUpdate(ctx, request.Plan, &response.State, &response.Diagnostics)
+	r.CreateUpdate(ctx, request.Plan, &response.State, response.Private, &response.Diagnostics)
 }
 
 func (r *AzapiResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
-	r.CreateUpdate(ctx, request.Plan, &response.State, &response.Diagnostics)
+	var plan, state *AzapiResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// the create_only_body is only sent when the resource is created, so no request is sent if it's the only change
+	if createOnlyBodyChangedOnly(*plan, *state) {
+		response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+		return
+	}
+
+	r.CreateUpdate(ctx, request.Plan, &response.State, response.Private, &response.Diagnostics)
 }
 
-func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan, responseState *tfsdk.State, diagnostics *diag.Diagnostics) {
+func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan, responseState *tfsdk.State, private privateState, diagnostics *diag.Diagnostics) {
 	var plan, state *AzapiResourceModel
 	diagnostics.Append(requestPlan.Get(ctx, &plan)...)
 	diagnostics.Append(responseState.Get(ctx, &state)...)
@@ -462,6 +775,8 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 		return
 	}
 
+	// the request context is canceled when Terraform is interrupted, the timeout context is also canceled when the timeout is exceeded
+	requestCtx := ctx
 	ctx, cancel := context.WithTimeout(ctx, createUpdateTimeout)
 	defer cancel()
 
@@ -473,9 +788,17 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 
 	client := r.ProviderData.ResourceClient
 	isNewResource := responseState == nil || responseState.Raw.IsNull()
-	if isNewResource {
+	resumeToken := ""
+	if !isNewResource {
+		// the long-running operation which was interrupted in the last apply
+		token, diags := getPendingOperation(ctx, private)
+		if diagnostics.Append(diags...); diagnostics.HasError() {
+			return
+		}
+		resumeToken = token
+	} else {
 		// check if the resource already exists
-		_, err = client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+		_, err = client.Get(ctx, id.AzureResourceId, id.ApiVersion, plan.readRequestOptions())
 		if err == nil {
 			diagnostics.AddError("Resource already exists", tf.ImportAsExistsError("azapi_resource", id.ID()).Error())
 			return
@@ -510,6 +833,14 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 		return
 	}
 
+	// the sensitive_body is always sent, the create_only_body is only sent when the resource is created
+	extraBody, err := writeOnlyBody(*plan, isNewResource)
+	if err != nil {
+		diagnostics.AddError("Invalid configuration", err.Error())
+		return
+	}
+	body = utils.MergeObject(body, extraBody).(map[string]interface{})
+
 	if !isNewResource {
 		// handle the case that identity block was once set, now it's removed
 		if stateIdentity := identity.FromList(state.Identity); body["identity"] == nil && stateIdentity.Type.ValueString() != string(identity.None) {
@@ -521,7 +852,7 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 		// handle the case that `ignore_body_changes` is set
 		if ignoreChanges := AsStringList(plan.IgnoreBodyChanges); len(ignoreChanges) != 0 {
 			// retrieve the existing resource
-			existing, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+			existing, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, plan.readRequestOptions())
 			if err != nil {
 				diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("reading %s: %+v", id, err).Error())
 				return
@@ -539,6 +870,15 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 
 			body = merged.(map[string]interface{})
 		}
+
+		// only send the top level properties which are changed since the last apply, the others are left untouched
+		if plan.UpdateMethod.ValueString() == http.MethodPatch {
+			previous, diags := previousBody(*state)
+			if diagnostics.Append(diags...); diagnostics.HasError() {
+				return
+			}
+			body = utils.PatchObject(previous, body).(map[string]interface{})
+		}
 	}
 
 	// create/update the resource
@@ -547,16 +887,93 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 		defer locks.UnlockByID(lockId)
 	}
 
-	responseBody, err := client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, body)
+	updateOptions := plan.updateRequestOptions()
+	if !isNewResource && plan.IfMatchEnabled.ValueBool() {
+		updateOptions = withIfMatch(updateOptions, state.ETag)
+	}
+
+	// resume polling the long-running operation which was interrupted in the last apply, instead of sending the request again
+	if resumeToken != "" {
+		tflog.Info(ctx, fmt.Sprintf("resuming the pending operation of %s", id))
+		_, err = client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, nil, clients.RequestOptions{ResumeToken: resumeToken})
+		if err != nil {
+			if clients.ResumeTokenFromError(err) == "" {
+				// the operation has failed, it's removed so that the request is sent again in the next apply
+				diagnostics.Append(setPendingOperation(ctx, private, "")...)
+			}
+			r.handleInterruptedOperation(requestCtx, err, id, plan, isNewResource, responseState, private, diagnostics)
+			return
+		}
+		if diagnostics.Append(setPendingOperation(ctx, private, "")...); diagnostics.HasError() {
+			return
+		}
+	}
+
+	var responseBody interface{}
+	etag := ""
+	switch {
+	case resumeToken != "" && !requestBodyChanged(*plan, *state):
+		// the resumed operation has applied the same request body, only refresh the computed fields
+		responseBody, etag, err = client.GetWithETag(ctx, id.AzureResourceId, id.ApiVersion, plan.readRequestOptions())
+	case isNewResource && plan.CreateOnCollection.ValueBool():
+		// the collection doesn't know the name from the URL, and its response may not be the created resource
+		body["name"] = id.Name
+		if _, err = client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, body, plan.createRequestOptions()); err == nil {
+			responseBody, err = client.Get(ctx, id.AzureResourceId, id.ApiVersion, plan.readRequestOptions())
+		}
+	case isNewResource:
+		responseBody, err = client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, body, plan.createRequestOptions())
+	case plan.UpdateMethod.ValueString() != http.MethodPatch:
+		responseBody, err = client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, body, updateOptions)
+	case len(body) == 0:
+		// there's nothing to patch, only refresh the computed fields
+		responseBody, etag, err = client.GetWithETag(ctx, id.AzureResourceId, id.ApiVersion, plan.readRequestOptions())
+	default:
+		responseBody, err = client.Patch(ctx, id.AzureResourceId, id.ApiVersion, body, updateOptions)
+	}
 	if err != nil {
-		diagnostics.AddError("Failed to create/update resource", fmt.Errorf("creating/updating %s: %+v", id, err).Error())
+		if utils.ResponseErrorWasPreconditionFailed(err) {
+			diagnostics.AddError("Resource changed outside Terraform", preconditionFailedError(id, state).Error())
+			return
+		}
+		r.handleInterruptedOperation(requestCtx, err, id, plan, isNewResource, responseState, private, diagnostics)
 		return
 	}
 
+	// wait until the condition in the `wait_for` block holds, the computed fields are generated from the last response
+	// the resource has been created/updated even if the waiting fails, so it's still stored in the state. The failure of
+	// a new resource is reported as a warning, because Terraform would taint the resource and replace it in the next apply.
+	var waitErr error
+	if waitFor := waitfor.ExpandWaitFor(plan.WaitFor); waitFor != nil {
+		if waitedBody, err := client.WaitFor(ctx, id.AzureResourceId, id.ApiVersion, waitFor, plan.readRequestOptions()); err == nil {
+			responseBody = waitedBody
+		} else {
+			waitErr = err
+		}
+	}
+
 	// generate the computed fields
 	plan.ID = types.StringValue(id.ID())
-	plan.Output = types.StringValue(flattenOutput(responseBody, AsStringList(plan.ResponseExportValues)))
-	plan.OutputPayload = types.DynamicValue(flattenOutputPayload(responseBody, AsStringList(plan.ResponseExportValues)))
+	if etag == "" {
+		etag = utils.GetETag(responseBody)
+	}
+	if etag == "" && plan.IfMatchEnabled.ValueBool() {
+		// some resources only return the ETag in the response header
+		if _, etag, err = client.GetWithETag(ctx, id.AzureResourceId, id.ApiVersion, plan.readRequestOptions()); err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("failed to retrieve the etag of %s: %+v", id, err))
+		}
+	}
+	plan.ETag = types.StringValue(etag)
+	outputBody, sensitiveBody := responseBody, interface{}(nil)
+	if plan.SensitiveOutputEnabled.ValueBool() {
+		outputBody, sensitiveBody = splitSensitiveOutput(id.ResourceDef, responseBody)
+	}
+	plan.Output = types.StringValue(flattenOutput(outputBody, AsStringList(plan.ResponseExportValues)))
+	plan.OutputPayload = types.DynamicValue(flattenOutputPayload(outputBody, AsStringList(plan.ResponseExportValues)))
+	plan.SensitiveOutput = types.DynamicNull()
+	if sensitiveBody != nil {
+		plan.SensitiveOutput = types.DynamicValue(flattenOutputPayload(sensitiveBody, AsStringList(plan.ResponseExportValues)))
+	}
 	if bodyMap, ok := responseBody.(map[string]interface{}); ok {
 		if !plan.Identity.IsNull() {
 			planIdentity := identity.FromList(plan.Identity)
@@ -572,6 +989,60 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 	}
 
 	diagnostics.Append(responseState.Set(ctx, plan)...)
+	switch {
+	case waitErr != nil && isNewResource:
+		diagnostics.AddWarning("Failed to wait for resource", fmt.Sprintf("The resource %s has been created, but the condition in the `wait_for` block doesn't hold: %+v", id, waitErr))
+	case waitErr != nil:
+		diagnostics.AddError("Failed to wait for resource", fmt.Errorf("waiting for %s: %+v", id, waitErr).Error())
+	}
+}
+
+// handleInterruptedOperation reports the error of the create/update operation. If the polling of the long-running operation is
+// interrupted, its resume token is stored in the private state, so the next apply resumes polling it instead of starting over.
+func (r *AzapiResource) handleInterruptedOperation(requestCtx context.Context, err error, id parse.ResourceId, plan *AzapiResourceModel, isNewResource bool, responseState *tfsdk.State, private privateState, diagnostics *diag.Diagnostics) {
+	resumeToken := clients.ResumeTokenFromError(err)
+	if resumeToken == "" {
+		diagnostics.AddError("Failed to create/update resource", fmt.Errorf("creating/updating %s: %+v", id, err).Error())
+		return
+	}
+
+	ctx := context.WithoutCancel(requestCtx)
+	if diagnostics.Append(setPendingOperation(ctx, private, resumeToken)...); diagnostics.HasError() {
+		return
+	}
+	if !isNewResource && requestCtx.Err() == nil {
+		// the prior state is kept, the next apply resumes polling the operation
+		diagnostics.AddError("Failed to create/update resource", fmt.Errorf("creating/updating %s: %+v\n\nThe operation is still in progress, the next apply will resume polling it.", id, err).Error())
+		return
+	}
+
+	// Terraform is interrupted, or the polling of a new resource fails, e.g. the timeout is exceeded. The planned state is
+	// stored without an error so that the resource isn't tainted, replacing the resource that is still being created is not expected
+	plan.ID = types.StringValue(id.ID())
+	if plan.ETag.IsUnknown() {
+		plan.ETag = types.StringNull()
+	}
+	if plan.Output.IsUnknown() {
+		plan.Output = types.StringNull()
+	}
+	if plan.OutputPayload.IsUnknown() {
+		plan.OutputPayload = types.DynamicNull()
+	}
+	if plan.SensitiveOutput.IsUnknown() {
+		plan.SensitiveOutput = types.DynamicNull()
+	}
+	if !plan.Identity.IsNull() {
+		planIdentity := identity.FromList(plan.Identity)
+		if planIdentity.TenantID.IsUnknown() {
+			planIdentity.TenantID = types.StringNull()
+		}
+		if planIdentity.PrincipalID.IsUnknown() {
+			planIdentity.PrincipalID = types.StringNull()
+		}
+		plan.Identity = identity.ToList(planIdentity)
+	}
+	diagnostics.Append(responseState.Set(ctx, plan)...)
+	diagnostics.AddWarning("Operation interrupted", fmt.Sprintf("The creation/update of %s was interrupted while the long-running operation was still in progress, the next apply will resume polling it: %+v", id, err))
 }
 
 func (r *AzapiResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
@@ -596,7 +1067,7 @@ func (r *AzapiResource) Read(ctx context.Context, request resource.ReadRequest,
 	}
 
 	client := r.ProviderData.ResourceClient
-	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	responseBody, etag, err := client.GetWithETag(ctx, id.AzureResourceId, id.ApiVersion, model.readRequestOptions())
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			tflog.Info(ctx, fmt.Sprintf("Error reading %q - removing from state", id.ID()))
@@ -608,6 +1079,7 @@ func (r *AzapiResource) Read(ctx context.Context, request resource.ReadRequest,
 	}
 
 	state := model
+	state.ETag = types.StringValue(etag)
 	state.Name = types.StringValue(id.Name)
 	state.ParentID = types.StringValue(id.ParentId)
 	state.Type = types.StringValue(fmt.Sprintf("%s@%s", id.AzureResourceType, id.ApiVersion))
@@ -677,8 +1149,16 @@ func (r *AzapiResource) Read(ctx context.Context, request resource.ReadRequest,
 			}
 		}
 	}
-	state.Output = types.StringValue(flattenOutput(responseBody, AsStringList(model.ResponseExportValues)))
-	state.OutputPayload = types.DynamicValue(flattenOutputPayload(responseBody, AsStringList(model.ResponseExportValues)))
+	outputBody, sensitiveBody := responseBody, interface{}(nil)
+	if model.SensitiveOutputEnabled.ValueBool() {
+		outputBody, sensitiveBody = splitSensitiveOutput(id.ResourceDef, responseBody)
+	}
+	state.Output = types.StringValue(flattenOutput(outputBody, AsStringList(model.ResponseExportValues)))
+	state.OutputPayload = types.DynamicValue(flattenOutputPayload(outputBody, AsStringList(model.ResponseExportValues)))
+	state.SensitiveOutput = types.DynamicNull()
+	if sensitiveBody != nil {
+		state.SensitiveOutput = types.DynamicValue(flattenOutputPayload(sensitiveBody, AsStringList(model.ResponseExportValues)))
+	}
 
 	if ignoreBodyChanges := AsStringList(model.IgnoreBodyChanges); len(ignoreBodyChanges) != 0 {
 		if out, err := overrideWithPaths(responseBody, requestBody, ignoreBodyChanges); err == nil {
@@ -746,8 +1226,28 @@ func (r *AzapiResource) Delete(ctx context.Context, request resource.DeleteReque
 		defer locks.UnlockByID(lockId)
 	}
 
-	_, err = client.Delete(ctx, id.AzureResourceId, id.ApiVersion)
+	// wait until the condition in the `wait_for` block holds before deleting the resource
+	if waitFor := waitfor.ExpandWaitFor(model.WaitFor); waitFor != nil && waitFor.BeforeDelete {
+		_, err = client.WaitFor(ctx, id.AzureResourceId, id.ApiVersion, waitFor, model.readRequestOptions())
+		if err != nil {
+			if utils.ResponseErrorWasNotFound(err) {
+				return
+			}
+			response.Diagnostics.AddError("Failed to wait for resource", fmt.Errorf("waiting for %s: %+v", id, err).Error())
+			return
+		}
+	}
+
+	options := model.deleteRequestOptions()
+	if model.IfMatchEnabled.ValueBool() {
+		options = withIfMatch(options, model.ETag)
+	}
+	_, err = client.Delete(ctx, id.AzureResourceId, id.ApiVersion, options)
 	if err != nil && !utils.ResponseErrorWasNotFound(err) {
+		if utils.ResponseErrorWasPreconditionFailed(err) {
+			response.Diagnostics.AddError("Resource changed outside Terraform", preconditionFailedError(id, model).Error())
+			return
+		}
 		response.Diagnostics.AddError("Failed to delete resource", fmt.Errorf("deleting %s: %+v", id, err).Error())
 	}
 }
@@ -779,22 +1279,37 @@ func (r *AzapiResource) ImportState(ctx context.Context, request resource.Import
 	client := r.ProviderData.ResourceClient
 
 	state := AzapiResourceModel{
-		ID:                      types.StringValue(id.ID()),
-		Name:                    types.StringValue(id.Name),
-		ParentID:                types.StringValue(id.ParentId),
-		Type:                    types.StringValue(fmt.Sprintf("%s@%s", id.AzureResourceType, id.ApiVersion)),
-		Locks:                   types.ListNull(types.StringType),
-		Identity:                types.ListNull(identity.Model{}.ModelType()),
-		Body:                    types.StringValue("{}"),
-		RemovingSpecialChars:    types.BoolValue(false),
-		SchemaValidationEnabled: types.BoolValue(true),
-		IgnoreBodyChanges:       types.ListNull(types.StringType),
-		IgnoreCasing:            types.BoolValue(false),
-		IgnoreMissingProperty:   types.BoolValue(true),
-		ResponseExportValues:    types.ListNull(types.StringType),
-		Output:                  types.StringValue("{}"),
-		OutputPayload:           types.DynamicNull(),
-		Tags:                    types.MapNull(types.StringType),
+		ID:                            types.StringValue(id.ID()),
+		Name:                          types.StringValue(id.Name),
+		ParentID:                      types.StringValue(id.ParentId),
+		Type:                          types.StringValue(fmt.Sprintf("%s@%s", id.AzureResourceType, id.ApiVersion)),
+		Locks:                         types.ListNull(types.StringType),
+		Identity:                      types.ListNull(identity.Model{}.ModelType()),
+		Body:                          types.StringValue("{}"),
+		SensitiveBody:                 types.DynamicNull(),
+		CreateOnlyBody:                types.DynamicNull(),
+		RemovingSpecialChars:          types.BoolValue(false),
+		SchemaValidationEnabled:       types.BoolValue(true),
+		IgnoreBodyChanges:             types.ListNull(types.StringType),
+		IgnoreCasing:                  types.BoolValue(false),
+		IgnoreMissingProperty:         types.BoolValue(true),
+		ResponseExportValues:          types.ListNull(types.StringType),
+		Output:                        types.StringValue("{}"),
+		OutputPayload:                 types.DynamicNull(),
+		SensitiveOutput:               types.DynamicNull(),
+		Tags:                          types.MapNull(types.StringType),
+		ReplaceTriggersExternalValues: types.DynamicNull(),
+		ReplaceTriggersRefs:           types.ListNull(types.StringType),
+		CreateQueryParameters:         types.MapNull(types.ListType{ElemType: types.StringType}),
+		CreateHeaders:                 types.MapNull(types.StringType),
+		ReadQueryParameters:           types.MapNull(types.ListType{ElemType: types.StringType}),
+		ReadHeaders:                   types.MapNull(types.StringType),
+		UpdateQueryParameters:         types.MapNull(types.ListType{ElemType: types.StringType}),
+		UpdateHeaders:                 types.MapNull(types.StringType),
+		DeleteQueryParameters:         types.MapNull(types.ListType{ElemType: types.StringType}),
+		DeleteHeaders:                 types.MapNull(types.StringType),
+		Retry:                         types.ObjectNull(retry.Model{}.AttrType()),
+		WaitFor:                       types.ObjectNull(waitfor.Model{}.AttrType()),
 		Timeouts: timeouts.Value{
 			Object: types.ObjectNull(map[string]attr.Type{
 				"create": types.StringType,
@@ -804,7 +1319,7 @@ func (r *AzapiResource) ImportState(ctx context.Context, request resource.Import
 		},
 	}
 
-	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	responseBody, etag, err := client.GetWithETag(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOptions{})
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			tflog.Info(ctx, fmt.Sprintf("[INFO] Error reading %q - removing from state", id.ID()))
@@ -816,6 +1331,7 @@ func (r *AzapiResource) ImportState(ctx context.Context, request resource.Import
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("resource %q is imported", id.ID()))
+	state.ETag = types.StringValue(etag)
 	if id.ResourceDef != nil {
 		writeOnlyBody := (*id.ResourceDef).GetWriteOnly(utils.NormalizeObject(responseBody))
 		if bodyMap, ok := writeOnlyBody.(map[string]interface{}); ok {
@@ -946,6 +1462,238 @@ func expandBody(body map[string]interface{}, model AzapiResourceModel) diag.Diag
 	return diag.Diagnostics{}
 }
 
+func (m AzapiResourceModel) createRequestOptions() clients.RequestOptions {
+	return clients.RequestOptions{
+		Retry:           retry.ExpandRetry(m.Retry),
+		Method:          m.CreateMethod.ValueString(),
+		UrlPathSuffix:   m.CreateAction.ValueString(),
+		Collection:      m.CreateOnCollection.ValueBool(),
+		QueryParameters: expandQueryParameters(m.CreateQueryParameters),
+		Headers:         expandHeaders(m.CreateHeaders),
+	}
+}
+
+func (m AzapiResourceModel) readRequestOptions() clients.RequestOptions {
+	return clients.RequestOptions{
+		Method:          m.ReadMethod.ValueString(),
+		UrlPathSuffix:   m.ReadAction.ValueString(),
+		QueryParameters: expandQueryParameters(m.ReadQueryParameters),
+		Headers:         expandHeaders(m.ReadHeaders),
+	}
+}
+
+func (m AzapiResourceModel) updateRequestOptions() clients.RequestOptions {
+	return clients.RequestOptions{
+		Retry:           retry.ExpandRetry(m.Retry),
+		Method:          m.UpdateMethod.ValueString(),
+		UrlPathSuffix:   m.UpdateAction.ValueString(),
+		QueryParameters: expandQueryParameters(m.UpdateQueryParameters),
+		Headers:         expandHeaders(m.UpdateHeaders),
+	}
+}
+
+func (m AzapiResourceModel) deleteRequestOptions() clients.RequestOptions {
+	return clients.RequestOptions{
+		Retry:           retry.ExpandRetry(m.Retry),
+		Method:          m.DeleteMethod.ValueString(),
+		UrlPathSuffix:   m.DeleteAction.ValueString(),
+		QueryParameters: expandQueryParameters(m.DeleteQueryParameters),
+		Headers:         expandHeaders(m.DeleteHeaders),
+	}
+}
+
+// withIfMatch adds the `If-Match` header with the etag to the request options, the header specified by users takes precedence.
+func withIfMatch(options clients.RequestOptions, etag types.String) clients.RequestOptions {
+	if etag.IsNull() || etag.IsUnknown() || etag.ValueString() == "" {
+		return options
+	}
+	headers := map[string]string{}
+	for key, value := range options.Headers {
+		if strings.EqualFold(key, "If-Match") {
+			return options
+		}
+		headers[key] = value
+	}
+	headers["If-Match"] = etag.ValueString()
+	options.Headers = headers
+	return options
+}
+
+func preconditionFailedError(id parse.ResourceId, model *AzapiResourceModel) error {
+	etag := ""
+	if model != nil {
+		etag = model.ETag.ValueString()
+	}
+	return fmt.Errorf("%s has been changed outside of Terraform since it was last read, its etag no longer matches %q. Please refresh the state and retry", id, etag)
+}
+
+// requestBodyChanged returns true if the arguments which build the request body are changed
+func requestBodyChanged(plan AzapiResourceModel, state AzapiResourceModel) bool {
+	return !plan.Identity.Equal(state.Identity) || !plan.Tags.Equal(state.Tags) || !plan.Location.Equal(state.Location) ||
+		utils.NormalizeJson(plan.Body.ValueString()) != utils.NormalizeJson(state.Body.ValueString()) ||
+		!plan.Payload.Equal(state.Payload) || !plan.SensitiveBody.Equal(state.SensitiveBody)
+}
+
+// createOnlyBodyChangedOnly returns true if the create_only_body is the only changed argument
+func createOnlyBodyChangedOnly(plan AzapiResourceModel, state AzapiResourceModel) bool {
+	if plan.CreateOnlyBody.Equal(state.CreateOnlyBody) {
+		return false
+	}
+	state.CreateOnlyBody = plan.CreateOnlyBody
+	state.ETag = plan.ETag
+	return reflect.DeepEqual(plan, state)
+}
+
+// previousBody returns the request body of the last apply, which is built from the state.
+func previousBody(state AzapiResourceModel) (map[string]interface{}, diag.Diagnostics) {
+	body := map[string]interface{}{}
+	switch {
+	case !state.Payload.IsNull():
+		out, err := expandPayload(state.Payload)
+		if err != nil {
+			return nil, diag.Diagnostics{
+				diag.NewErrorDiagnostic("Invalid payload", fmt.Sprintf(`The argument "payload" in the state is invalid: %+v`, err)),
+			}
+		}
+		body = out
+	case !state.Body.IsNull() && state.Body.ValueString() != "":
+		if err := json.Unmarshal([]byte(state.Body.ValueString()), &body); err != nil {
+			return nil, diag.Diagnostics{
+				diag.NewErrorDiagnostic("Invalid JSON string", fmt.Sprintf(`The argument "body" in the state is invalid: value: %s, err: %+v`, state.Body.ValueString(), err)),
+			}
+		}
+	}
+	if body == nil {
+		body = map[string]interface{}{}
+	}
+	return body, expandBody(body, state)
+}
+
+// deployTimeConstantChanges compares the deploy-time constant properties in the planned body with the ones in the state,
+// and returns the changes of the properties. The properties which are missing in the state are not compared, and the
+// sensitive values are not shown in the changes.
+func deployTimeConstantChanges(body map[string]interface{}, plan AzapiResourceModel, state AzapiResourceModel, resourceDef *aztypes.ResourceType) ([]string, diag.Diagnostics) {
+	after := make(map[string]interface{})
+	for key, value := range body {
+		after[key] = value
+	}
+	if diags := expandBody(after, plan); diags.HasError() {
+		return nil, diags
+	}
+	before, diags := previousBody(state)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// the location is compared separately, the changes of the name and location are handled by the plan modifiers
+	delete(before, "location")
+	delete(after, "location")
+
+	var planned interface{} = after
+	if ignoreChanges := AsStringList(plan.IgnoreBodyChanges); len(ignoreChanges) != 0 {
+		var err error
+		if planned, err = overrideWithPaths(after, before, ignoreChanges); err != nil {
+			return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Invalid configuration", err.Error())}
+		}
+	}
+
+	beforeConstants := resourceDef.GetDeployTimeConstant(utils.NormalizeObject(before))
+	afterConstants := resourceDef.GetDeployTimeConstant(utils.NormalizeObject(planned))
+	// the properties are compared one by one, so that the ones which are missing in the state can be skipped
+	if beforeConstants == nil {
+		beforeConstants = map[string]interface{}{}
+	}
+	if afterConstants == nil {
+		afterConstants = map[string]interface{}{}
+	}
+	option := utils.UpdateJsonOption{IgnoreCasing: true}
+
+	// the sensitive values are not shown in the changes, but they're still compared
+	redactedChanges := make(map[string]string)
+	redactedBefore := utils.RedactObject(beforeConstants, resourceDef.GetSensitive(beforeConstants))
+	redactedAfter := utils.RedactObject(afterConstants, resourceDef.GetSensitive(afterConstants))
+	for _, change := range utils.DiffObjectWithOption(redactedBefore, redactedAfter, option) {
+		redactedChanges[change[:strings.Index(change, ": ")]] = change
+	}
+
+	changes := make([]string, 0)
+	for _, change := range utils.DiffObjectWithOption(beforeConstants, afterConstants, option) {
+		changePath := change[:strings.Index(change, ": ")]
+		// the properties which are missing in the state, e.g. the imported resources, are not compared
+		if strings.HasPrefix(change, changePath+": (not specified) =>") {
+			continue
+		}
+		if redactedChanges[changePath] != change {
+			change = fmt.Sprintf("%s: (sensitive value)", changePath)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// externalValuesChanged returns true if the planned external values are different from the ones in the state,
+// the unknown values are treated as changed.
+func externalValuesChanged(plan types.Dynamic, state types.Dynamic) bool {
+	if plan.Equal(state) {
+		return false
+	}
+	if plan.IsNull() || state.IsNull() || plan.IsUnknown() || state.IsUnknown() || plan.IsUnderlyingValueUnknown() {
+		return true
+	}
+	return !dynamic.SemanticallyEqual(plan, state)
+}
+
+// replaceTriggersRefsChanges returns the paths in replace_triggers_refs whose values in the planned body are different
+// from the ones in the state.
+func replaceTriggersRefsChanges(body map[string]interface{}, plan AzapiResourceModel, state AzapiResourceModel) ([]string, diag.Diagnostics) {
+	refs := AsStringList(plan.ReplaceTriggersRefs)
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	after := make(map[string]interface{})
+	for key, value := range body {
+		after[key] = value
+	}
+	if diags := expandBody(after, plan); diags.HasError() {
+		return nil, diags
+	}
+	before, diags := previousBody(state)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	changes := make([]string, 0)
+	for _, ref := range refs {
+		beforeValue := utils.NormalizeObject(utils.ExtractObject(before, ref))
+		afterValue := utils.NormalizeObject(utils.ExtractObject(after, ref))
+		if !reflect.DeepEqual(beforeValue, afterValue) {
+			changes = append(changes, ref)
+		}
+	}
+	return changes, nil
+}
+
+// writeOnlyBody builds the properties which are sent in the request but not tracked in the body, the `sensitive_body`
+// is always included and the `create_only_body` is only included when the resource is created. The unknown values are ignored.
+func writeOnlyBody(model AzapiResourceModel, isNewResource bool) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	if isNewResource && !model.CreateOnlyBody.IsNull() && !model.CreateOnlyBody.IsUnknown() && !model.CreateOnlyBody.IsUnderlyingValueUnknown() {
+		createOnlyBody, err := expandPayload(model.CreateOnlyBody)
+		if err != nil {
+			return nil, fmt.Errorf(`the argument "create_only_body" is invalid: %+v`, err)
+		}
+		out = utils.MergeObject(out, createOnlyBody).(map[string]interface{})
+	}
+	if !model.SensitiveBody.IsNull() && !model.SensitiveBody.IsUnknown() && !model.SensitiveBody.IsUnderlyingValueUnknown() {
+		sensitiveBody, err := expandPayload(model.SensitiveBody)
+		if err != nil {
+			return nil, fmt.Errorf(`the argument "sensitive_body" is invalid: %+v`, err)
+		}
+		out = utils.MergeObject(out, sensitiveBody).(map[string]interface{})
+	}
+	return out, nil
+}
+
 func validateDuplicatedDefinitions(model *AzapiResourceModel, body map[string]interface{}) diag.Diagnostics {
 	diags := diag.Diagnostics{}
 	if !model.Tags.IsNull() && !model.Tags.IsUnknown() && body["tags"] != nil {
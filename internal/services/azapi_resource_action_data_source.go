@@ -9,6 +9,7 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -20,17 +21,18 @@ import (
 )
 
 type ResourceActionDataSourceModel struct {
-	ID                   types.String   `tfsdk:"id"`
-	ResourceID           types.String   `tfsdk:"resource_id"`
-	Type                 types.String   `tfsdk:"type"`
-	Action               types.String   `tfsdk:"action"`
-	Method               types.String   `tfsdk:"method"`
-	Body                 types.String   `tfsdk:"body"`
-	Payload              types.Dynamic  `tfsdk:"payload"`
-	ResponseExportValues types.List     `tfsdk:"response_export_values"`
-	Output               types.String   `tfsdk:"output"`
-	OutputPayload        types.Dynamic  `tfsdk:"output_payload"`
-	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+	ID                      types.String   `tfsdk:"id"`
+	ResourceID              types.String   `tfsdk:"resource_id"`
+	Type                    types.String   `tfsdk:"type"`
+	Action                  types.String   `tfsdk:"action"`
+	Method                  types.String   `tfsdk:"method"`
+	Body                    types.String   `tfsdk:"body"`
+	Payload                 types.Dynamic  `tfsdk:"payload"`
+	ResponseExportValues    types.List     `tfsdk:"response_export_values"`
+	Output                  types.String   `tfsdk:"output"`
+	OutputPayload           types.Dynamic  `tfsdk:"output_payload"`
+	SchemaValidationEnabled types.Bool     `tfsdk:"schema_validation_enabled"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
 
 type ResourceActionDataSource struct {
@@ -100,7 +102,7 @@ func (r *ResourceActionDataSource) Schema(ctx context.Context, request datasourc
 				Optional:    true,
 				ElementType: types.StringType,
 				Validators: []validator.List{
-					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsExtractPath()),
 				},
 			},
 
@@ -112,6 +114,10 @@ func (r *ResourceActionDataSource) Schema(ctx context.Context, request datasourc
 			"output_payload": schema.DynamicAttribute{
 				Computed: true,
 			},
+
+			"schema_validation_enabled": schema.BoolAttribute{
+				Optional: true,
+			},
 		},
 
 		Blocks: map[string]schema.Block{
@@ -185,8 +191,20 @@ func (r *ResourceActionDataSource) Read(ctx context.Context, request datasource.
 		method = "POST"
 	}
 
+	if model.SchemaValidationEnabled.IsNull() || model.SchemaValidationEnabled.ValueBool() {
+		azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(model.Type.ValueString())
+		if err != nil {
+			response.Diagnostics.AddError("Invalid configuration", fmt.Sprintf(`The argument "type" is invalid: %s`, err.Error()))
+			return
+		}
+		if err := actionSchemaValidation(azureResourceType, apiVersion, model.Action.ValueString(), method, requestBody); err != nil {
+			response.Diagnostics.AddError("Invalid configuration", err.Error())
+			return
+		}
+	}
+
 	client := r.ProviderData.ResourceClient
-	responseBody, err := client.Action(ctx, id.AzureResourceId, model.Action.ValueString(), id.ApiVersion, method, requestBody)
+	responseBody, err := client.Action(ctx, id.AzureResourceId, model.Action.ValueString(), id.ApiVersion, method, requestBody, clients.RequestOptions{})
 	if err != nil {
 		response.Diagnostics.AddError("Failed to perform action", fmt.Errorf("performing action %s of %q: %+v", model.Action.ValueString(), id, err).Error())
 		return
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/locks"
+	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/defaults"
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myplanmodifier"
@@ -29,19 +30,23 @@ import (
 )
 
 type ActionResourceModel struct {
-	ID                   types.String   `tfsdk:"id"`
-	Type                 types.String   `tfsdk:"type"`
-	ResourceId           types.String   `tfsdk:"resource_id"`
-	Action               types.String   `tfsdk:"action"`
-	Method               types.String   `tfsdk:"method"`
-	Body                 types.String   `tfsdk:"body"`
-	Payload              types.Dynamic  `tfsdk:"payload"`
-	When                 types.String   `tfsdk:"when"`
-	Locks                types.List     `tfsdk:"locks"`
-	ResponseExportValues types.List     `tfsdk:"response_export_values"`
-	Output               types.String   `tfsdk:"output"`
-	OutputPayload        types.Dynamic  `tfsdk:"output_payload"`
-	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+	ID                      types.String   `tfsdk:"id"`
+	Type                    types.String   `tfsdk:"type"`
+	ResourceId              types.String   `tfsdk:"resource_id"`
+	Action                  types.String   `tfsdk:"action"`
+	Method                  types.String   `tfsdk:"method"`
+	Body                    types.String   `tfsdk:"body"`
+	Payload                 types.Dynamic  `tfsdk:"payload"`
+	When                    types.String   `tfsdk:"when"`
+	Locks                   types.List     `tfsdk:"locks"`
+	Headers                 types.Map      `tfsdk:"headers"`
+	QueryParameters         types.Map      `tfsdk:"query_parameters"`
+	ResponseExportValues    types.List     `tfsdk:"response_export_values"`
+	Output                  types.String   `tfsdk:"output"`
+	OutputPayload           types.Dynamic  `tfsdk:"output_payload"`
+	SchemaValidationEnabled types.Bool     `tfsdk:"schema_validation_enabled"`
+	Retry                   types.Object   `tfsdk:"retry"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
 
 type ActionResource struct {
@@ -146,11 +151,15 @@ func (r *ActionResource) Schema(ctx context.Context, request resource.SchemaRequ
 				},
 			},
 
+			"headers": headersAttribute(),
+
+			"query_parameters": queryParametersAttribute(),
+
 			"response_export_values": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Validators: []validator.List{
-					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsExtractPath()),
 				},
 			},
 
@@ -162,9 +171,15 @@ func (r *ActionResource) Schema(ctx context.Context, request resource.SchemaRequ
 			"output_payload": schema.DynamicAttribute{
 				Computed: true,
 			},
+
+			"schema_validation_enabled": schema.BoolAttribute{
+				Optional: true,
+			},
 		},
 
 		Blocks: map[string]schema.Block{
+			"retry": retry.Block(),
+
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
 				Read:   true,
@@ -205,11 +220,45 @@ func (r *ActionResource) ModifyPlan(ctx context.Context, request resource.Modify
 		return
 	}
 
-	if state == nil || !plan.ResponseExportValues.Equal(state.ResponseExportValues) || utils.NormalizeJson(plan.Body.ValueString()) != utils.NormalizeJson(state.Body.ValueString()) || !plan.Payload.Equal(state.Payload) {
+	if state == nil || !plan.ResponseExportValues.Equal(state.ResponseExportValues) || utils.NormalizeJson(plan.Body.ValueString()) != utils.NormalizeJson(state.Body.ValueString()) || !plan.Payload.Equal(state.Payload) ||
+		!plan.Headers.Equal(state.Headers) || !plan.QueryParameters.Equal(state.QueryParameters) {
 		plan.Output = types.StringUnknown()
 		plan.OutputPayload = basetypes.NewDynamicUnknown()
 		response.Diagnostics.Append(response.Plan.Set(ctx, plan)...)
 	}
+
+	if !config.SchemaValidationEnabled.IsNull() && !config.SchemaValidationEnabled.ValueBool() {
+		return
+	}
+	if config.Type.IsUnknown() || config.Action.IsUnknown() || config.Method.IsUnknown() || config.Body.IsUnknown() || config.Payload.IsUnknown() {
+		return
+	}
+
+	var body interface{}
+	switch {
+	case !config.Payload.IsNull():
+		out, err := expandPayload(config.Payload)
+		if err != nil {
+			return
+		}
+		body = out
+	case !config.Body.IsNull():
+		if err := json.Unmarshal([]byte(config.Body.ValueString()), &body); err != nil {
+			return
+		}
+	default:
+		body = map[string]interface{}{}
+	}
+
+	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(config.Type.ValueString())
+	if err != nil {
+		return
+	}
+	err = actionSchemaValidation(azureResourceType, apiVersion, config.Action.ValueString(), plan.Method.ValueString(), body)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid configuration", err.Error())
+		return
+	}
 }
 
 func (r *ActionResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
@@ -305,7 +354,11 @@ func (r *ActionResource) Action(ctx context.Context, model ActionResourceModel,
 	}
 
 	client := r.ProviderData.ResourceClient
-	responseBody, err := client.Action(ctx, id.AzureResourceId, model.Action.ValueString(), id.ApiVersion, model.Method.ValueString(), requestBody)
+	responseBody, err := client.Action(ctx, id.AzureResourceId, model.Action.ValueString(), id.ApiVersion, model.Method.ValueString(), requestBody, clients.RequestOptions{
+		Retry:           retry.ExpandRetry(model.Retry),
+		Headers:         expandHeaders(model.Headers),
+		QueryParameters: expandQueryParameters(model.QueryParameters),
+	})
 	if err != nil {
 		diagnostics.AddError("Failed to perform action", fmt.Errorf("performing action %s of %q: %+v", model.Action.ValueString(), id, err).Error())
 		return
@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
@@ -90,6 +91,18 @@ func TestAccActionResource_nonstandardLRO(t *testing.T) {
 	})
 }
 
+func TestAccActionResource_invalidAction(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource_action", "test")
+	r := ActionResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      r.invalidAction(data),
+			ExpectError: regexp.MustCompile("the argument \"action\" is invalid"),
+		},
+	})
+}
+
 func (r ActionResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -271,3 +284,15 @@ resource "azapi_resource_action" "test" {
 
 `, data.LocationPrimary, data.RandomStringOfLength(10))
 }
+
+func (r ActionResource) invalidAction(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azapi_resource_action" "test" {
+  type        = "Microsoft.Automation/automationAccounts@2021-06-22"
+  resource_id = azapi_resource.test.id
+  action      = "listKey"
+}
+`, GenericResource{}.defaultTag(data))
+}
@@ -24,18 +24,20 @@ import (
 )
 
 type AzapiResourceDataSourceModel struct {
-	ID                   types.String   `tfsdk:"id"`
-	Name                 types.String   `tfsdk:"name"`
-	ParentID             types.String   `tfsdk:"parent_id"`
-	ResourceID           types.String   `tfsdk:"resource_id"`
-	Type                 types.String   `tfsdk:"type"`
-	ResponseExportValues types.List     `tfsdk:"response_export_values"`
-	Location             types.String   `tfsdk:"location"`
-	Identity             types.List     `tfsdk:"identity"`
-	Output               types.String   `tfsdk:"output"`
-	OutputPayload        types.Dynamic  `tfsdk:"output_payload"`
-	Tags                 types.Map      `tfsdk:"tags"`
-	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+	ID                     types.String   `tfsdk:"id"`
+	Name                   types.String   `tfsdk:"name"`
+	ParentID               types.String   `tfsdk:"parent_id"`
+	ResourceID             types.String   `tfsdk:"resource_id"`
+	Type                   types.String   `tfsdk:"type"`
+	ResponseExportValues   types.List     `tfsdk:"response_export_values"`
+	Location               types.String   `tfsdk:"location"`
+	Identity               types.List     `tfsdk:"identity"`
+	Output                 types.String   `tfsdk:"output"`
+	OutputPayload          types.Dynamic  `tfsdk:"output_payload"`
+	SensitiveOutputEnabled types.Bool     `tfsdk:"sensitive_output_enabled"`
+	SensitiveOutput        types.Dynamic  `tfsdk:"sensitive_output"`
+	Tags                   types.Map      `tfsdk:"tags"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 type AzapiResourceDataSource struct {
@@ -125,7 +127,7 @@ func (r *AzapiResourceDataSource) Schema(ctx context.Context, request datasource
 				Optional:    true,
 				ElementType: types.StringType,
 				Validators: []validator.List{
-					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsExtractPath()),
 				},
 			},
 
@@ -138,6 +140,15 @@ func (r *AzapiResourceDataSource) Schema(ctx context.Context, request datasource
 				Computed: true,
 			},
 
+			"sensitive_output_enabled": schema.BoolAttribute{
+				Optional: true,
+			},
+
+			"sensitive_output": schema.DynamicAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
 			"tags": schema.MapAttribute{
 				Computed:    true,
 				ElementType: types.StringType,
@@ -195,7 +206,7 @@ func (r *AzapiResourceDataSource) Read(ctx context.Context, request datasource.R
 	}
 
 	client := r.ProviderData.ResourceClient
-	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOptions{})
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			response.Diagnostics.AddError("Resource not found", fmt.Errorf("resource %q not found", id).Error())
@@ -220,8 +231,16 @@ func (r *AzapiResourceDataSource) Read(ctx context.Context, request datasource.R
 			model.Identity = identity.ToList(*v)
 		}
 	}
-	model.Output = basetypes.NewStringValue(flattenOutput(responseBody, AsStringList(model.ResponseExportValues)))
-	model.OutputPayload = types.DynamicValue(flattenOutputPayload(responseBody, AsStringList(model.ResponseExportValues)))
+	outputBody, sensitiveBody := responseBody, interface{}(nil)
+	if model.SensitiveOutputEnabled.ValueBool() {
+		outputBody, sensitiveBody = splitSensitiveOutput(id.ResourceDef, responseBody)
+	}
+	model.Output = basetypes.NewStringValue(flattenOutput(outputBody, AsStringList(model.ResponseExportValues)))
+	model.OutputPayload = types.DynamicValue(flattenOutputPayload(outputBody, AsStringList(model.ResponseExportValues)))
+	model.SensitiveOutput = types.DynamicNull()
+	if sensitiveBody != nil {
+		model.SensitiveOutput = types.DynamicValue(flattenOutputPayload(sensitiveBody, AsStringList(model.ResponseExportValues)))
+	}
 
 	response.Diagnostics.Append(response.State.Set(ctx, &model)...)
 }
@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/location"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/tags"
+	azuretypes "github.com/Azure/terraform-provider-azapi/internal/azure/types"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
+	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// resourcesApiVersion is the api-version used to list the generic resources under a subscription or resource group.
+	resourcesApiVersion = "2021-04-01"
+	// managementGroupDescendantsApiVersion is the api-version used to list the subscriptions under a management group.
+	managementGroupDescendantsApiVersion = "2020-05-01"
+)
+
+type ResourceDiscoveryDataSourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	ParentID      types.String   `tfsdk:"parent_id"`
+	ResourceTypes types.List     `tfsdk:"resource_types"`
+	Tags          types.Map      `tfsdk:"tags"`
+	Locations     types.List     `tfsdk:"locations"`
+	IncludeBody   types.Bool     `tfsdk:"include_body"`
+	Resources     types.List     `tfsdk:"resources"`
+	OutputPayload types.Dynamic  `tfsdk:"output_payload"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+type DiscoveredResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	ApiVersion types.String `tfsdk:"api_version"`
+	Location   types.String `tfsdk:"location"`
+	Tags       types.Map    `tfsdk:"tags"`
+}
+
+func (DiscoveredResourceModel) AttrType() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":          types.StringType,
+		"name":        types.StringType,
+		"type":        types.StringType,
+		"api_version": types.StringType,
+		"location":    types.StringType,
+		"tags":        types.MapType{ElemType: types.StringType},
+	}
+}
+
+type ResourceDiscoveryDataSource struct {
+	ProviderData *clients.Client
+}
+
+var _ datasource.DataSource = &ResourceDiscoveryDataSource{}
+var _ datasource.DataSourceWithConfigure = &ResourceDiscoveryDataSource{}
+
+func (r *ResourceDiscoveryDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if v, ok := request.ProviderData.(*clients.Client); ok {
+		r.ProviderData = v
+	}
+}
+
+func (r *ResourceDiscoveryDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_resource_discovery"
+}
+
+func (r *ResourceDiscoveryDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"parent_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					myvalidator.StringIsResourceID(),
+				},
+			},
+
+			"resource_types": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+				},
+			},
+
+			"tags": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"locations": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+				},
+			},
+
+			"include_body": schema.BoolAttribute{
+				Optional: true,
+			},
+
+			"resources": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"api_version": schema.StringAttribute{
+							Computed: true,
+						},
+						"location": schema.StringAttribute{
+							Computed: true,
+						},
+						"tags": schema.MapAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+
+			"output_payload": schema.DynamicAttribute{
+				Computed: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *ResourceDiscoveryDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var model ResourceDiscoveryDataSourceModel
+	if response.Diagnostics.Append(request.Config.Get(ctx, &model)...); response.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 30*time.Minute)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	resourceTypes := AsStringList(model.ResourceTypes)
+	for _, pattern := range resourceTypes {
+		if _, err := path.Match(strings.ToLower(pattern), ""); err != nil {
+			response.Diagnostics.AddError("Invalid configuration", fmt.Sprintf(`The argument "resource_types" contains an invalid pattern %q: %s`, pattern, err.Error()))
+			return
+		}
+	}
+
+	parentId := strings.TrimSuffix(model.ParentID.ValueString(), "/")
+	client := r.ProviderData.ResourceClient
+
+	var listUrls []string
+	switch utils.GetScopeType(parentId) {
+	case azuretypes.ResourceGroup, azuretypes.Subscription:
+		listUrls = []string{fmt.Sprintf("%s/resources", parentId)}
+	case azuretypes.ManagementGroup:
+		subscriptionIds, err := listManagementGroupSubscriptions(ctx, client, parentId)
+		if err != nil {
+			response.Diagnostics.AddError("Failed to list subscriptions", fmt.Sprintf("Failed to list subscriptions under %s, error: %s", parentId, err.Error()))
+			return
+		}
+		for _, subscriptionId := range subscriptionIds {
+			listUrls = append(listUrls, fmt.Sprintf("/subscriptions/%s/resources", subscriptionId))
+		}
+	default:
+		response.Diagnostics.AddError("Invalid configuration", fmt.Sprintf(`The argument "parent_id" is invalid: %q is not a subscription, resource group or management group ID`, parentId))
+		return
+	}
+
+	filterTags := tags.ExpandTags(model.Tags)
+	filterLocations := make(map[string]bool)
+	for _, v := range AsStringList(model.Locations) {
+		filterLocations[location.Normalize(v)] = true
+	}
+
+	apiVersions := make(map[string]string)
+	resources := make([]DiscoveredResourceModel, 0)
+	bodies := make(map[string]interface{})
+	for _, listUrl := range listUrls {
+		responseBody, err := client.List(ctx, listUrl, resourcesApiVersion, clients.RequestOptions{})
+		if err != nil {
+			response.Diagnostics.AddError("Failed to list resources", fmt.Sprintf("Failed to list resources, url: %s, error: %s", listUrl, err.Error()))
+			return
+		}
+
+		for _, item := range listValues(responseBody) {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, _ := itemMap["id"].(string)
+			name, _ := itemMap["name"].(string)
+			resourceType, _ := itemMap["type"].(string)
+			resourceLocation, _ := itemMap["location"].(string)
+			resourceLocation = location.Normalize(resourceLocation)
+			resourceTags, _ := itemMap["tags"].(map[string]interface{})
+
+			if !matchResourceTypePatterns(resourceTypes, resourceType) {
+				continue
+			}
+			if len(filterLocations) != 0 && !filterLocations[resourceLocation] {
+				continue
+			}
+			if !matchTags(filterTags, resourceTags) {
+				continue
+			}
+
+			apiVersion, ok := apiVersions[strings.ToLower(resourceType)]
+			if !ok {
+				apiVersion = latestApiVersion(azure.GetApiVersions(resourceType))
+				apiVersions[strings.ToLower(resourceType)] = apiVersion
+			}
+
+			resources = append(resources, DiscoveredResourceModel{
+				ID:         types.StringValue(id),
+				Name:       types.StringValue(name),
+				Type:       types.StringValue(resourceType),
+				ApiVersion: types.StringValue(apiVersion),
+				Location:   types.StringValue(resourceLocation),
+				Tags:       tags.FlattenTags(itemMap["tags"]),
+			})
+
+			if model.IncludeBody.ValueBool() {
+				if apiVersion == "" {
+					tflog.Warn(ctx, fmt.Sprintf("skip reading the body of %s, no api-version of resource type %s is found in the embedded schema", id, resourceType))
+					bodies[id] = nil
+					continue
+				}
+				body, err := client.Get(ctx, id, apiVersion, clients.RequestOptions{})
+				if err != nil {
+					if utils.ResponseErrorWasNotFound(err) {
+						bodies[id] = nil
+						continue
+					}
+					response.Diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("reading %s: %+v", id, err).Error())
+					return
+				}
+				bodies[id] = body
+			}
+		}
+	}
+
+	resourcesValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: DiscoveredResourceModel{}.AttrType()}, resources)
+	if response.Diagnostics.Append(diags...); response.Diagnostics.HasError() {
+		return
+	}
+
+	model.ID = basetypes.NewStringValue(parentId)
+	model.Resources = resourcesValue
+	model.OutputPayload = types.DynamicNull()
+	if model.IncludeBody.ValueBool() {
+		data, err := json.Marshal(bodies)
+		if err != nil {
+			response.Diagnostics.AddError("Invalid body", err.Error())
+			return
+		}
+		payload, err := dynamic.FromJSONImplied(data)
+		if err != nil {
+			response.Diagnostics.AddError("Invalid payload", err.Error())
+			return
+		}
+		model.OutputPayload = payload
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &model)...)
+}
+
+// listManagementGroupSubscriptions returns the IDs of all subscriptions under the management group, including the ones in its child management groups.
+func listManagementGroupSubscriptions(ctx context.Context, client *clients.ResourceClient, managementGroupId string) ([]string, error) {
+	responseBody, err := client.List(ctx, fmt.Sprintf("%s/descendants", managementGroupId), managementGroupDescendantsApiVersion, clients.RequestOptions{})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0)
+	for _, item := range listValues(responseBody) {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if resourceType, _ := itemMap["type"].(string); strings.EqualFold(resourceType, "Microsoft.Management/managementGroups/subscriptions") {
+			if name, ok := itemMap["name"].(string); ok {
+				res = append(res, name)
+			}
+		}
+	}
+	return res, nil
+}
+
+func listValues(responseBody interface{}) []interface{} {
+	if responseMap, ok := responseBody.(map[string]interface{}); ok {
+		if value, ok := responseMap["value"].([]interface{}); ok {
+			return value
+		}
+	}
+	return nil
+}
+
+// matchResourceTypePatterns returns true if the resource type matches any of the glob patterns, the patterns are case-insensitive.
+func matchResourceTypePatterns(patterns []string, resourceType string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(resourceType)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTags returns true if the resource tags contain all the expected tags.
+func matchTags(expected map[string]string, actual map[string]interface{}) bool {
+	for key, value := range expected {
+		found := false
+		for k, v := range actual {
+			if strings.EqualFold(k, key) && v == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// latestApiVersion returns the latest stable api-version, or the latest preview api-version if there's no stable one.
+func latestApiVersion(apiVersions []string) string {
+	for i := len(apiVersions) - 1; i >= 0; i-- {
+		if !strings.Contains(strings.ToLower(apiVersions[i]), "preview") {
+			return apiVersions[i]
+		}
+	}
+	if len(apiVersions) != 0 {
+		return apiVersions[len(apiVersions)-1]
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type ResourceDiscoveryDataSource struct{}
+
+func TestAccResourceDiscoveryDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_resource_discovery", "test")
+	r := ResourceDiscoveryDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("resources.#").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAccResourceDiscoveryDataSource_filters(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_resource_discovery", "test")
+	r := ResourceDiscoveryDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.filters(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("resources.#").HasValue("1"),
+				check.That(data.ResourceName).Key("resources.0.type").HasValue("Microsoft.Automation/automationAccounts"),
+				check.That(data.ResourceName).Key("resources.0.api_version").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccResourceDiscoveryDataSource_includeBody(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_resource_discovery", "test")
+	r := ResourceDiscoveryDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.includeBody(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("resources.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (r ResourceDiscoveryDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azapi_resource" "resourceGroup" {
+  type     = "Microsoft.Resources/resourceGroups@2023-07-01"
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azapi_resource" "automationAccount" {
+  type      = "Microsoft.Automation/automationAccounts@2023-11-01"
+  name      = "acctest%[1]d"
+  parent_id = azapi_resource.resourceGroup.id
+  location  = "%[2]s"
+  body = {
+    properties = {
+      sku = {
+        name = "Basic"
+      }
+    }
+  }
+  tags = {
+    environment = "test"
+  }
+}
+
+resource "azapi_resource" "userAssignedIdentity" {
+  type      = "Microsoft.ManagedIdentity/userAssignedIdentities@2023-01-31"
+  name      = "acctest%[1]d"
+  parent_id = azapi_resource.resourceGroup.id
+  location  = "%[2]s"
+}
+`, data.RandomInteger, data.LocationPrimary)
+}
+
+func (r ResourceDiscoveryDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azapi_resource_discovery" "test" {
+  parent_id = azapi_resource.resourceGroup.id
+
+  depends_on = [azapi_resource.automationAccount, azapi_resource.userAssignedIdentity]
+}
+`, r.template(data))
+}
+
+func (r ResourceDiscoveryDataSource) filters(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azapi_resource_discovery" "test" {
+  parent_id      = azapi_resource.resourceGroup.id
+  resource_types = ["microsoft.automation/*", "Microsoft.ManagedIdentity/userAssignedIdentities"]
+  locations      = [azapi_resource.resourceGroup.location]
+  tags = {
+    environment = "test"
+  }
+
+  depends_on = [azapi_resource.automationAccount, azapi_resource.userAssignedIdentity]
+}
+`, r.template(data))
+}
+
+func (r ResourceDiscoveryDataSource) includeBody(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azapi_resource_discovery" "test" {
+  parent_id      = azapi_resource.resourceGroup.id
+  resource_types = ["Microsoft.Automation/automationAccounts"]
+  include_body   = true
+
+  depends_on = [azapi_resource.automationAccount, azapi_resource.userAssignedIdentity]
+}
+`, r.template(data))
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
+	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	resourceGraphId         = "/providers/Microsoft.ResourceGraph"
+	resourceGraphApiVersion = "2021-03-01"
+)
+
+type ResourceGraphQueryDataSourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Query              types.String   `tfsdk:"query"`
+	SubscriptionIDs    types.List     `tfsdk:"subscription_ids"`
+	ManagementGroupIDs types.List     `tfsdk:"management_group_ids"`
+	OutputPayload      types.Dynamic  `tfsdk:"output_payload"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+type ResourceGraphQueryDataSource struct {
+	ProviderData *clients.Client
+}
+
+var _ datasource.DataSource = &ResourceGraphQueryDataSource{}
+var _ datasource.DataSourceWithConfigure = &ResourceGraphQueryDataSource{}
+
+func (r *ResourceGraphQueryDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if v, ok := request.ProviderData.(*clients.Client); ok {
+		r.ProviderData = v
+	}
+}
+
+func (r *ResourceGraphQueryDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_resource_graph_query"
+}
+
+func (r *ResourceGraphQueryDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"query": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					myvalidator.StringIsNotEmpty(),
+				},
+			},
+
+			"subscription_ids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+				},
+			},
+
+			"management_group_ids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+				},
+			},
+
+			"output_payload": schema.DynamicAttribute{
+				Computed: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *ResourceGraphQueryDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var model ResourceGraphQueryDataSourceModel
+	if response.Diagnostics.Append(request.Config.Get(ctx, &model)...); response.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	requestBody := map[string]interface{}{
+		"query": model.Query.ValueString(),
+	}
+	if subscriptionIds := AsStringList(model.SubscriptionIDs); len(subscriptionIds) != 0 {
+		subscriptions := make([]string, 0)
+		for _, v := range subscriptionIds {
+			subscriptions = append(subscriptions, strings.TrimPrefix(v, "/subscriptions/"))
+		}
+		requestBody["subscriptions"] = subscriptions
+	}
+	if managementGroupIds := AsStringList(model.ManagementGroupIDs); len(managementGroupIds) != 0 {
+		managementGroups := make([]string, 0)
+		for _, v := range managementGroupIds {
+			if strings.HasPrefix(v, "/") {
+				v = utils.GetName(v)
+			}
+			managementGroups = append(managementGroups, v)
+		}
+		requestBody["managementGroups"] = managementGroups
+	}
+
+	client := r.ProviderData.ResourceClient
+	rows := make([]interface{}, 0)
+	skipToken := ""
+	for {
+		options := map[string]interface{}{
+			"resultFormat": "objectArray",
+		}
+		if skipToken != "" {
+			options["$skipToken"] = skipToken
+		}
+		requestBody["options"] = options
+
+		responseBody, err := client.Action(ctx, resourceGraphId, "resources", resourceGraphApiVersion, "POST", requestBody, clients.RequestOptions{})
+		if err != nil {
+			response.Diagnostics.AddError("Failed to query resource graph", fmt.Errorf("querying resource graph: %+v", err).Error())
+			return
+		}
+
+		responseMap, ok := responseBody.(map[string]interface{})
+		if !ok {
+			response.Diagnostics.AddError("Failed to query resource graph", fmt.Sprintf("unexpected response: %v", responseBody))
+			return
+		}
+		if data, ok := responseMap["data"].([]interface{}); ok {
+			rows = append(rows, data...)
+		}
+		skipToken, _ = responseMap["$skipToken"].(string)
+		if skipToken == "" {
+			break
+		}
+	}
+
+	data, err := json.Marshal(rows)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid body", err.Error())
+		return
+	}
+	payload, err := dynamic.FromJSONImplied(data)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid payload", err.Error())
+		return
+	}
+
+	model.ID = basetypes.NewStringValue(fmt.Sprintf("%s/resources", resourceGraphId))
+	model.OutputPayload = payload
+
+	response.Diagnostics.Append(response.State.Set(ctx, &model)...)
+}
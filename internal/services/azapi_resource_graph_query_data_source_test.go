@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type ResourceGraphQueryDataSource struct{}
+
+func TestAccResourceGraphQueryDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_resource_graph_query", "test")
+	r := ResourceGraphQueryDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output_payload.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccResourceGraphQueryDataSource_paging(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_resource_graph_query", "test")
+	r := ResourceGraphQueryDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.paging(),
+			Check: resource.ComposeTestCheckFunc(
+				// the 1600 rows are more than the 1000 rows returned in one page
+				check.That(data.ResourceName).Key("output_payload.#").HasValue("1600"),
+			),
+		},
+	})
+}
+
+func (r ResourceGraphQueryDataSource) basic() string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+data "azapi_resource_graph_query" "test" {
+  query            = "ResourceContainers | where type =~ 'microsoft.resources/subscriptions' | project id, name"
+  subscription_ids = [data.azurerm_client_config.current.subscription_id]
+}
+`
+}
+
+func (r ResourceGraphQueryDataSource) paging() string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+data "azapi_resource_graph_query" "test" {
+  query            = "ResourceContainers | where type =~ 'microsoft.resources/subscriptions' | extend i = range(1, 40, 1), j = range(1, 40, 1) | mv-expand i | mv-expand j | project id, i, j"
+  subscription_ids = [data.azurerm_client_config.current.subscription_id]
+}
+`
+}
@@ -2,14 +2,11 @@ package services
 
 import (
 	"context"
-	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -165,30 +162,15 @@ func (r *ResourceIdDataSource) Read(ctx context.Context, request datasource.Read
 	model.ParentID = basetypes.NewStringValue(id.ParentId)
 	model.ResourceID = basetypes.NewStringValue(id.AzureResourceId)
 
-	armId, err := arm.ParseResourceID(id.AzureResourceId)
-	if id.AzureResourceId == "/" {
-		armId, err = &arm.ResourceID{
-			ResourceType: arm.TenantResourceType,
-		}, nil
-	}
+	armId, err := armResourceID(id.AzureResourceId)
 	if err != nil {
 		response.Diagnostics.AddError("Invalid configuration", err.Error())
 		return
 	}
-
 	model.ResourceGroupName = basetypes.NewStringValue(armId.ResourceGroupName)
 	model.SubscriptionID = basetypes.NewStringValue(armId.SubscriptionID)
 	model.ProviderNamespace = basetypes.NewStringValue(armId.ResourceType.Namespace)
-
-	path := id.AzureResourceId
-	path = strings.TrimPrefix(path, "/")
-	path = strings.TrimSuffix(path, "/")
-	components := strings.Split(path, "/")
-	parts := make(map[string]attr.Value)
-	for i := 0; i < len(components)-1; i += 2 {
-		parts[components[i]] = basetypes.NewStringValue(components[i+1])
-	}
-	model.Parts = basetypes.NewMapValueMust(types.StringType, parts)
+	model.Parts = resourceIdParts(id.AzureResourceId)
 
 	response.Diagnostics.Append(response.State.Set(ctx, &model)...)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -22,6 +23,9 @@ type ResourceListDataSourceModel struct {
 	ID                   types.String   `tfsdk:"id"`
 	Type                 types.String   `tfsdk:"type"`
 	ParentID             types.String   `tfsdk:"parent_id"`
+	Query                types.String   `tfsdk:"query"`
+	Headers              types.Map      `tfsdk:"headers"`
+	QueryParameters      types.Map      `tfsdk:"query_parameters"`
 	ResponseExportValues types.List     `tfsdk:"response_export_values"`
 	Output               types.String   `tfsdk:"output"`
 	OutputPayload        types.Dynamic  `tfsdk:"output_payload"`
@@ -66,11 +70,28 @@ func (r *ResourceListDataSource) Schema(ctx context.Context, request datasource.
 				},
 			},
 
+			"query": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.StringIsQuery(),
+				},
+			},
+
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"query_parameters": schema.MapAttribute{
+				ElementType: types.ListType{ElemType: types.StringType},
+				Optional:    true,
+			},
+
 			"response_export_values": schema.ListAttribute{
 				Optional:    true,
 				ElementType: types.StringType,
 				Validators: []validator.List{
-					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsExtractPath()),
 				},
 			},
 
@@ -116,15 +137,31 @@ func (r *ResourceListDataSource) Read(ctx context.Context, request datasource.Re
 	listUrl := strings.TrimSuffix(id.AzureResourceId, "/")
 
 	client := r.ProviderData.ResourceClient
-	responseBody, err := client.List(ctx, listUrl, id.ApiVersion)
+	responseBody, err := client.List(ctx, listUrl, id.ApiVersion, clients.RequestOptions{
+		Headers:         expandHeaders(model.Headers),
+		QueryParameters: expandQueryParameters(model.QueryParameters),
+	})
 	if err != nil {
 		response.Diagnostics.AddError("Failed to list resources", fmt.Sprintf("Failed to list resources, url: %s, error: %s", listUrl, err.Error()))
 		return
 	}
 
+	responseExportValues := AsStringList(model.ResponseExportValues)
+	if query := model.Query.ValueString(); query != "" {
+		responseBody, err = utils.QueryObject(responseBody, query)
+		if err != nil {
+			response.Diagnostics.AddError("Failed to query resources", fmt.Sprintf("Failed to evaluate the query %q, error: %s", query, err.Error()))
+			return
+		}
+		// the query result is exported as a whole if no paths are specified
+		if len(responseExportValues) == 0 {
+			responseExportValues = []string{"*"}
+		}
+	}
+
 	model.ID = basetypes.NewStringValue(listUrl)
-	model.Output = basetypes.NewStringValue(flattenOutput(responseBody, AsStringList(model.ResponseExportValues)))
-	model.OutputPayload = types.DynamicValue(flattenOutputPayload(responseBody, AsStringList(model.ResponseExportValues)))
+	model.Output = basetypes.NewStringValue(flattenOutput(responseBody, responseExportValues))
+	model.OutputPayload = types.DynamicValue(flattenOutputPayload(responseBody, responseExportValues))
 
 	response.Diagnostics.Append(response.State.Set(ctx, &model)...)
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -33,6 +34,35 @@ func TestAccListDataSource_paging(t *testing.T) {
 	})
 }
 
+func TestAccListDataSource_query(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_resource_list", "test")
+	r := ListDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.query(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output_payload.#").HasValue("1"),
+				check.That(data.ResourceName).Key("output_payload.0.name").HasValue("Contributor"),
+			),
+		},
+	})
+}
+
+func TestAccListDataSource_queryParameters(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_resource_list", "test")
+	r := ListDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.queryParameters(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output_payload.#").HasValue("1"),
+			),
+		},
+	})
+}
+
 func (r ListDataSource) basic() string {
 	return `
 provider "azurerm" {
@@ -64,3 +94,38 @@ data "azapi_resource_list" "test" {
 }
 `
 }
+
+func (r ListDataSource) query() string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+data "azapi_resource_list" "test" {
+  type      = "Microsoft.Authorization/roleDefinitions@2022-04-01"
+  parent_id = "/subscriptions/${data.azurerm_client_config.current.subscription_id}"
+  query     = "value[?properties.roleName == 'Contributor'].{id: id, name: properties.roleName}"
+}
+`
+}
+
+func (r ListDataSource) queryParameters() string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+data "azapi_resource_list" "test" {
+  type      = "Microsoft.Authorization/roleDefinitions@2022-04-01"
+  parent_id = "/subscriptions/${data.azurerm_client_config.current.subscription_id}"
+  query_parameters = {
+    "$filter" = ["roleName eq 'Reader'"]
+  }
+  query = "value[*].properties.roleName"
+}
+`
+}
@@ -4,17 +4,28 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sync/atomic"
 	"testing"
 
 	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
 	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
 	"github.com/Azure/terraform-provider-azapi/internal/azure/location"
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
 	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	frameworkresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -22,7 +33,7 @@ import (
 type GenericResource struct{}
 
 func defaultIgnores() []string {
-	return []string{"ignore_casing", "ignore_missing_property", "schema_validation_enabled", "body", "locks", "removing_special_chars", "payload"}
+	return []string{"ignore_casing", "ignore_missing_property", "schema_validation_enabled", "body", "locks", "removing_special_chars", "payload", "retry", "update_method", "create_headers", "delete_query_parameters", "if_match_enabled"}
 }
 
 var testCertRaw, _ = os.ReadFile(filepath.Join("testdata", "automation_certificate_test.pfx"))
@@ -493,6 +504,72 @@ func TestAccGenericResource_nonstandardLRO(t *testing.T) {
 	})
 }
 
+func TestAccGenericResource_replaceTriggersExternalValues(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.replaceTriggersExternalValues(data, "1.0.0"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(append(defaultIgnores(), "replace_triggers_external_values")...),
+		{
+			Config: r.replaceTriggersExternalValues(data, "2.0.0"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(append(defaultIgnores(), "replace_triggers_external_values")...),
+	})
+}
+
+func TestAccGenericResource_replaceTriggersRefs(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.replaceTriggersRefs(data, "10.0.0.0/16"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(append(defaultIgnores(), "replace_triggers_refs")...),
+		{
+			Config: r.replaceTriggersRefs(data, "10.1.0.0/16"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(append(defaultIgnores(), "replace_triggers_refs")...),
+	})
+}
+
+func TestAccGenericResource_sensitiveBody(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.sensitiveBody(data, "P@ssw0rd1234!"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(append(defaultIgnores(), "sensitive_body", "create_only_body")...),
+		{
+			Config: r.sensitiveBody(data, "P@ssw0rd5678!"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(append(defaultIgnores(), "sensitive_body", "create_only_body")...),
+	})
+}
+
 func (GenericResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	resourceType := state.Attributes["type"]
 	id, err := parse.ResourceIDWithResourceType(state.ID, resourceType)
@@ -500,7 +577,7 @@ func (GenericResource) Exists(ctx context.Context, client *clients.Client, state
 		return nil, err
 	}
 
-	_, err = client.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	_, err = client.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOptions{})
 	if err == nil {
 		b := true
 		return &b, nil
@@ -522,6 +599,133 @@ func (GenericResource) ImportIdFunc(tfState *terraform.State) (string, error) {
 	return fmt.Sprintf("%s?api-version=%s", id.AzureResourceId, id.ApiVersion), nil
 }
 
+func TestAzapiResource_createOnlyBodyChangeSendsNoRequest(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	r := &services.AzapiResource{ProviderData: testProviderData(t, server)}
+	attributes := map[string]attr.Value{
+		"id":        types.StringValue(testResourceId),
+		"type":      types.StringValue("Microsoft.Automation/automationAccounts@2023-11-01"),
+		"name":      types.StringValue("test"),
+		"parent_id": types.StringValue("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg"),
+		"body":      types.StringValue("{}"),
+	}
+	attributes["create_only_body"] = types.DynamicValue(types.StringValue("first"))
+	prior := testPlan(t, r, attributes)
+	attributes["create_only_body"] = types.DynamicValue(types.StringValue("second"))
+	plan := testPlan(t, r, attributes)
+
+	request := frameworkresource.UpdateRequest{Plan: plan, State: tfsdk.State{Schema: prior.Schema, Raw: prior.Raw}}
+	response := frameworkresource.UpdateResponse{State: tfsdk.State{Schema: prior.Schema, Raw: prior.Raw}}
+	r.Update(context.Background(), request, &response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %+v", response.Diagnostics)
+	}
+	if requests.Load() != 0 {
+		t.Fatalf("expected no requests, got %d", requests.Load())
+	}
+	if !response.State.Raw.Equal(plan.Raw) {
+		t.Fatalf("expected the planned create_only_body to be stored in the state")
+	}
+}
+
+func TestAccGenericResource_retry(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.retry(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(defaultIgnores()...),
+	})
+}
+
+func TestAccGenericResource_waitFor(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.waitFor(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("output_payload.properties.state").HasValue("Ok"),
+			),
+		},
+		data.ImportStep(append(defaultIgnores(), "wait_for")...),
+	})
+}
+
+func TestAccGenericResource_updateMethodPatch(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.updateMethodPatch(data, "Enabled"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(defaultIgnores()...),
+		{
+			Config: r.updateMethodPatch(data, "Disabled"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(defaultIgnores()...),
+	})
+}
+
+func TestAccGenericResource_customRequests(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.customRequests(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(defaultIgnores()...),
+	})
+}
+
+func TestAccGenericResource_ifMatch(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.ifMatch(data, "10.0.0.0/16"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("etag").Exists(),
+			),
+		},
+		data.ImportStep(defaultIgnores()...),
+		{
+			Config: r.ifMatch(data, "10.1.0.0/16"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("etag").Exists(),
+			),
+		},
+		data.ImportStep(defaultIgnores()...),
+	})
+}
+
 func (r GenericResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -1404,6 +1608,214 @@ resource "azapi_resource" "test" {
 `, r.template(data), data.RandomStringOfLength(10))
 }
 
+func (r GenericResource) retry(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Automation/automationAccounts@2023-11-01"
+  name      = "acctest%[2]s"
+  parent_id = azurerm_resource_group.test.id
+  location  = azurerm_resource_group.test.location
+
+  body = jsonencode({
+    properties = {
+      sku = {
+        name = "Basic"
+      }
+    }
+  })
+
+  retry {
+    error_message_regex      = ["AnotherOperationInProgress"]
+    status_codes             = [429]
+    interval_seconds         = 5
+    multiplier               = 2
+    max_elapsed_time_seconds = 300
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
+func (r GenericResource) waitFor(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Automation/automationAccounts@2023-11-01"
+  name      = "acctest%[2]s"
+  parent_id = azurerm_resource_group.test.id
+  location  = azurerm_resource_group.test.location
+
+  body = jsonencode({
+    properties = {
+      sku = {
+        name = "Basic"
+      }
+    }
+  })
+
+  response_export_values = ["properties.state"]
+
+  wait_for {
+    path             = "properties.state"
+    values           = ["Ok"]
+    interval_seconds = 5
+    timeout_seconds  = 300
+    before_delete    = true
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
+func (r GenericResource) updateMethodPatch(data acceptance.TestData, publicNetworkAccess string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azapi_resource" "test" {
+  type          = "Microsoft.ContainerRegistry/registries@2023-07-01"
+  name          = "acctest%[2]s"
+  parent_id     = azurerm_resource_group.test.id
+  location      = azurerm_resource_group.test.location
+  update_method = "PATCH"
+
+  body = jsonencode({
+    sku = {
+      name = "Premium"
+    }
+    properties = {
+      adminUserEnabled    = false
+      publicNetworkAccess = "%[3]s"
+    }
+  })
+
+  tags = {
+    env = "test"
+  }
+}
+`, r.template(data), data.RandomString, publicNetworkAccess)
+}
+
+func (r GenericResource) customRequests(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azapi_resource" "test" {
+  type     = "Microsoft.Resources/resourceGroups@2023-07-01"
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+
+  create_headers = {
+    "Accept-Language" = "en-US"
+  }
+  delete_query_parameters = {
+    forceDeletionTypes = ["Microsoft.Compute/virtualMachines"]
+  }
+}
+`, data.RandomInteger, data.LocationPrimary)
+}
+
+func (r GenericResource) ifMatch(data acceptance.TestData, addressPrefix string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azapi_resource" "test" {
+  type             = "Microsoft.Network/virtualNetworks@2023-04-01"
+  name             = "acctest%[2]s"
+  parent_id        = azurerm_resource_group.test.id
+  location         = azurerm_resource_group.test.location
+  if_match_enabled = true
+
+  body = jsonencode({
+    properties = {
+      addressSpace = {
+        addressPrefixes = ["%[3]s"]
+      }
+    }
+  })
+}
+`, r.template(data), data.RandomString, addressPrefix)
+}
+
+func (r GenericResource) replaceTriggersExternalValues(data acceptance.TestData, imageVersion string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.ManagedIdentity/userAssignedIdentities@2023-01-31"
+  name      = "acctest%[2]s"
+  parent_id = azurerm_resource_group.test.id
+  location  = azurerm_resource_group.test.location
+
+  replace_triggers_external_values = {
+    image_version = "%[3]s"
+  }
+}
+`, r.template(data), data.RandomString, imageVersion)
+}
+
+func (r GenericResource) replaceTriggersRefs(data acceptance.TestData, addressPrefix string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Network/virtualNetworks@2023-04-01"
+  name      = "acctest%[2]s"
+  parent_id = azurerm_resource_group.test.id
+  location  = azurerm_resource_group.test.location
+
+  replace_triggers_refs = ["properties.addressSpace.addressPrefixes"]
+
+  body = jsonencode({
+    properties = {
+      addressSpace = {
+        addressPrefixes = ["%[3]s"]
+      }
+    }
+  })
+}
+`, r.template(data), data.RandomString, addressPrefix)
+}
+
+func (r GenericResource) sensitiveBody(data acceptance.TestData, password string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azapi_resource" "automationAccount" {
+  type      = "Microsoft.Automation/automationAccounts@2023-11-01"
+  name      = "acctest%[2]s"
+  parent_id = azurerm_resource_group.test.id
+  location  = azurerm_resource_group.test.location
+  body = jsonencode({
+    properties = {
+      sku = {
+        name = "Basic"
+      }
+    }
+  })
+}
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Automation/automationAccounts/credentials@2023-11-01"
+  name      = "acctest%[2]s"
+  parent_id = azapi_resource.automationAccount.id
+  body = jsonencode({
+    properties = {
+      userName = "admin"
+    }
+  })
+  sensitive_body = {
+    properties = {
+      password = "%[3]s"
+    }
+  }
+  create_only_body = {
+    properties = {
+      description = "created by terraform"
+    }
+  }
+}
+`, r.template(data), data.RandomString, password)
+}
+
 func (GenericResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -1416,3 +1828,60 @@ resource "azurerm_resource_group" "test" {
 }
 `, data.RandomInteger, data.LocationPrimary, data.RandomStringOfLength(10))
 }
+
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p fakePrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestAzapiResource_createTimeoutKeepsPendingOperation(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/operations/1":
+			_, _ = w.Write([]byte(`{"status":"InProgress"}`))
+		case r.Method == http.MethodPut:
+			w.Header().Set("Azure-AsyncOperation", server.URL+"/operations/1")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"properties":{"provisioningState":"Creating"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"ResourceNotFound","message":"not found"}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	r := &services.AzapiResource{ProviderData: testProviderData(t, server)}
+	plan := testPlan(t, r, map[string]attr.Value{
+		"type":      types.StringValue("Microsoft.Automation/automationAccounts@2023-11-01"),
+		"name":      types.StringValue("test"),
+		"parent_id": types.StringValue("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg"),
+		"body":      types.StringValue("{}"),
+	})
+	if diags := plan.SetAttribute(context.Background(), path.Root("timeouts").AtName("create"), types.StringValue("2s")); diags.HasError() {
+		t.Fatalf("setting timeouts: %+v", diags)
+	}
+	state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+	private := fakePrivateState{}
+
+	var diags diag.Diagnostics
+	r.CreateUpdate(context.Background(), plan, &state, private, &diags)
+	if diags.HasError() || len(diags.Warnings()) != 1 || diags.Warnings()[0].Summary() != "Operation interrupted" {
+		t.Fatalf("expected only the interrupted operation warning, got %+v", diags)
+	}
+	if len(private["pending_operation"]) == 0 {
+		t.Fatalf("expected the pending operation to be stored in the private state")
+	}
+	var id types.String
+	if d := state.GetAttribute(context.Background(), path.Root("id"), &id); d.HasError() || id.ValueString() != testResourceId {
+		t.Fatalf("expected the planned state to be stored, got id %v: %+v", id, d)
+	}
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/internal/services/typed"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// typedResourceTypes are the resource types which are exposed as typed resources.
+var typedResourceTypes = []string{
+	"Microsoft.Resources/resourceGroups@2023-07-01",
+	"Microsoft.ManagedIdentity/userAssignedIdentities@2023-01-31",
+	"Microsoft.Network/virtualNetworks@2023-09-01",
+	"Microsoft.Storage/storageAccounts@2023-01-01",
+	"Microsoft.KeyVault/vaults@2023-07-01",
+}
+
+// TypedResources returns the typed resources, their schemas are generated from the embedded resource definitions
+// when the schemas are requested, so the definitions aren't loaded when the provider is created.
+func TypedResources() []func() resource.Resource {
+	out := make([]func() resource.Resource, 0, len(typedResourceTypes))
+	for _, v := range typedResourceTypes {
+		resourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(v)
+		if err != nil {
+			log.Printf("[WARN] skipping typed resource %q: %+v", v, err)
+			continue
+		}
+		out = append(out, func() resource.Resource {
+			return &TypedResource{
+				ResourceType: resourceType,
+				ApiVersion:   apiVersion,
+			}
+		})
+	}
+	return out
+}
+
+type TypedResource struct {
+	ProviderData *clients.Client
+	ResourceType string
+	ApiVersion   string
+
+	once     sync.Once
+	bodyNode *typed.Node
+	nodeErr  error
+}
+
+var _ resource.Resource = &TypedResource{}
+var _ resource.ResourceWithConfigure = &TypedResource{}
+var _ resource.ResourceWithImportState = &TypedResource{}
+
+func (r *TypedResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if v, ok := request.ProviderData.(*clients.Client); ok {
+		r.ProviderData = v
+	}
+}
+
+func (r *TypedResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_typed_" + typed.ResourceName(r.ResourceType, r.ApiVersion)
+}
+
+func (r *TypedResource) node() (*typed.Node, error) {
+	r.once.Do(func() {
+		resourceDef, err := azure.GetResourceDefinition(r.ResourceType, r.ApiVersion)
+		if err != nil {
+			r.nodeErr = err
+			return
+		}
+		r.bodyNode, r.nodeErr = typed.NewBodyNode(resourceDef)
+	})
+	return r.bodyNode, r.nodeErr
+}
+
+func (r *TypedResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	// the schema is requested with the other resources' schemas, a missing definition only disables this resource
+	attributes := map[string]schema.Attribute{}
+	node, err := r.node()
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("skipping the attributes of typed resource %s@%s, its definition is unavailable: %+v", r.ResourceType, r.ApiVersion, err))
+	} else {
+		attributes = typed.SchemaAttributes(node)
+	}
+	attributes["id"] = schema.StringAttribute{
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["parent_id"] = schema.StringAttribute{
+		Required: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			myvalidator.StringIsResourceID(),
+		},
+	}
+
+	response.Schema = schema.Schema{
+		Description: fmt.Sprintf("Manages a %s resource with API version %s.", r.ResourceType, r.ApiVersion),
+		Attributes:  attributes,
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *TypedResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	r.CreateUpdate(ctx, request.Plan, &response.State, &response.Diagnostics)
+}
+
+func (r *TypedResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	r.CreateUpdate(ctx, request.Plan, &response.State, &response.Diagnostics)
+}
+
+func (r *TypedResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan, responseState *tfsdk.State, diagnostics *diag.Diagnostics) {
+	var plan map[string]tftypes.Value
+	if err := requestPlan.Raw.As(&plan); err != nil {
+		diagnostics.AddError("Invalid plan", err.Error())
+		return
+	}
+
+	var timeoutsValue timeouts.Value
+	if diagnostics.Append(requestPlan.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...); diagnostics.HasError() {
+		return
+	}
+	createUpdateTimeout, diags := timeoutsValue.Create(ctx, 30*time.Minute)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createUpdateTimeout)
+	defer cancel()
+
+	node, err := r.node()
+	if err != nil {
+		diagnostics.AddError("Failed to load resource definition", err.Error())
+		return
+	}
+
+	var name, parentId string
+	if err := plan["name"].As(&name); err != nil {
+		diagnostics.AddError("Invalid name", err.Error())
+		return
+	}
+	if err := plan["parent_id"].As(&parentId); err != nil {
+		diagnostics.AddError("Invalid parent_id", err.Error())
+		return
+	}
+	id, err := parse.NewResourceID(name, parentId, r.typeWithVersion())
+	if err != nil {
+		diagnostics.AddError("Invalid configuration", err.Error())
+		return
+	}
+
+	body, err := typed.ExpandAttributes(node, plan)
+	if err != nil {
+		diagnostics.AddError("Invalid configuration", err.Error())
+		return
+	}
+	delete(body, "name")
+
+	client := r.ProviderData.ResourceClient
+	responseBody, err := client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, body, clients.RequestOptions{})
+	if err != nil {
+		diagnostics.AddError("Failed to create/update resource", fmt.Errorf("creating/updating %s: %+v", id, err).Error())
+		return
+	}
+
+	// the response of the PUT request might not contain all the properties, e.g. the asynchronous operation's response
+	if getResponse, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOptions{}); err == nil {
+		responseBody = getResponse
+	}
+
+	plan["id"] = tftypes.NewValue(tftypes.String, id.ID())
+	r.setState(ctx, node, plan, responseBody, typed.FlattenApply, responseState, diagnostics)
+}
+
+func (r *TypedResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state map[string]tftypes.Value
+	if err := request.State.Raw.As(&state); err != nil {
+		response.Diagnostics.AddError("Invalid state", err.Error())
+		return
+	}
+
+	var timeoutsValue timeouts.Value
+	if response.Diagnostics.Append(request.State.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...); response.Diagnostics.HasError() {
+		return
+	}
+	readTimeout, diags := timeoutsValue.Read(ctx, 5*time.Minute)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	node, err := r.node()
+	if err != nil {
+		response.Diagnostics.AddError("Failed to load resource definition", err.Error())
+		return
+	}
+
+	var resourceId string
+	if err := state["id"].As(&resourceId); err != nil {
+		response.Diagnostics.AddError("Invalid id", err.Error())
+		return
+	}
+	id, err := parse.ResourceIDWithResourceType(resourceId, r.typeWithVersion())
+	if err != nil {
+		response.Diagnostics.AddError("Error parsing ID", err.Error())
+		return
+	}
+
+	client := r.ProviderData.ResourceClient
+	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOptions{})
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("Error reading %q - removing from state", id.ID()))
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("reading %s: %+v", id, err).Error())
+		return
+	}
+
+	state["parent_id"] = tftypes.NewValue(tftypes.String, id.ParentId)
+	r.setState(ctx, node, state, responseBody, typed.FlattenRead, &response.State, &response.Diagnostics)
+}
+
+func (r *TypedResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state map[string]tftypes.Value
+	if err := request.State.Raw.As(&state); err != nil {
+		response.Diagnostics.AddError("Invalid state", err.Error())
+		return
+	}
+
+	var timeoutsValue timeouts.Value
+	if response.Diagnostics.Append(request.State.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...); response.Diagnostics.HasError() {
+		return
+	}
+	deleteTimeout, diags := timeoutsValue.Delete(ctx, 30*time.Minute)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var resourceId string
+	if err := state["id"].As(&resourceId); err != nil {
+		response.Diagnostics.AddError("Invalid id", err.Error())
+		return
+	}
+	id, err := parse.ResourceIDWithResourceType(resourceId, r.typeWithVersion())
+	if err != nil {
+		response.Diagnostics.AddError("Error parsing ID", err.Error())
+		return
+	}
+
+	client := r.ProviderData.ResourceClient
+	_, err = client.Delete(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOptions{})
+	if err != nil && !utils.ResponseErrorWasNotFound(err) {
+		response.Diagnostics.AddError("Failed to delete resource", fmt.Errorf("deleting %s: %+v", id, err).Error())
+	}
+}
+
+func (r *TypedResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	tflog.Debug(ctx, fmt.Sprintf("Importing Resource - parsing %q", request.ID))
+
+	id, err := parse.ResourceIDWithResourceType(request.ID, r.typeWithVersion())
+	if err != nil {
+		response.Diagnostics.AddError("Invalid Resource ID", fmt.Errorf("parsing Resource ID %q: %+v", request.ID, err).Error())
+		return
+	}
+
+	node, err := r.node()
+	if err != nil {
+		response.Diagnostics.AddError("Failed to load resource definition", err.Error())
+		return
+	}
+
+	client := r.ProviderData.ResourceClient
+	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOptions{})
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("[INFO] Error reading %q - removing from state", id.ID()))
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("reading %s: %+v", id, err).Error())
+		return
+	}
+
+	var state map[string]tftypes.Value
+	if err := response.State.Raw.As(&state); err != nil || state == nil {
+		state = make(map[string]tftypes.Value)
+	}
+	state["id"] = tftypes.NewValue(tftypes.String, id.ID())
+	state["parent_id"] = tftypes.NewValue(tftypes.String, id.ParentId)
+	r.setState(ctx, node, state, responseBody, typed.FlattenImport, &response.State, &response.Diagnostics)
+
+	tflog.Info(ctx, fmt.Sprintf("resource %q is imported", id.ID()))
+}
+
+// setState merges the response body into the prior values of the body attributes and sets the state.
+func (r *TypedResource) setState(ctx context.Context, node *typed.Node, prior map[string]tftypes.Value, responseBody interface{}, mode typed.FlattenMode, state *tfsdk.State, diagnostics *diag.Diagnostics) {
+	values, err := typed.FlattenAttributes(node, prior, responseBody, mode)
+	if err != nil {
+		diagnostics.AddError("Failed to flatten response", err.Error())
+		return
+	}
+
+	stateType := state.Schema.Type().TerraformType(ctx)
+	objectType, ok := stateType.(tftypes.Object)
+	if !ok {
+		diagnostics.AddError("Invalid schema", fmt.Sprintf("unexpected state type %s", stateType))
+		return
+	}
+	for key, attrType := range objectType.AttributeTypes {
+		if value, ok := values[key]; ok {
+			prior[key] = value
+			continue
+		}
+		if _, ok := prior[key]; !ok {
+			prior[key] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	state.Raw = tftypes.NewValue(stateType, prior)
+}
+
+func (r *TypedResource) typeWithVersion() string {
+	return fmt.Sprintf("%s@%s", r.ResourceType, r.ApiVersion)
+}
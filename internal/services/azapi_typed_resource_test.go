@@ -0,0 +1,116 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+type TypedResource struct {
+	ResourceType string
+}
+
+func TestAccTypedResource_resourceGroup(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_typed_microsoft_resources_resource_groups_2023_07_01", "test")
+	r := TypedResource{ResourceType: "Microsoft.Resources/resourceGroups@2023-07-01"}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.resourceGroup(data, "dev"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("dev"),
+				check.That(data.ResourceName).Key("properties.provisioning_state").HasValue("Succeeded"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.resourceGroup(data, "prod"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("prod"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccTypedResource_userAssignedIdentity(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_typed_microsoft_managed_identity_user_assigned_identities_2023_01_31", "test")
+	r := TypedResource{ResourceType: "Microsoft.ManagedIdentity/userAssignedIdentities@2023-01-31"}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.userAssignedIdentity(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("properties.principal_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r TypedResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	id, err := parse.ResourceIDWithResourceType(state.ID, r.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOptions{})
+	if err == nil {
+		b := true
+		return &b, nil
+	}
+	if utils.ResponseErrorWasNotFound(err) {
+		b := false
+		return &b, nil
+	}
+	return nil, fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+}
+
+func (r TypedResource) resourceGroup(data acceptance.TestData, environment string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azapi_typed_microsoft_resources_resource_groups_2023_07_01" "test" {
+  parent_id = "/subscriptions/${data.azurerm_client_config.current.subscription_id}"
+  name      = "acctestRG-%[1]d"
+  location  = "%[2]s"
+  tags = {
+    environment = "%[3]s"
+  }
+}
+`, data.RandomInteger, data.LocationPrimary, environment)
+}
+
+func (r TypedResource) userAssignedIdentity(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azapi_typed_microsoft_managed_identity_user_assigned_identities_2023_01_31" "test" {
+  parent_id = azurerm_resource_group.test.id
+  name      = "acctest%[3]s"
+  location  = azurerm_resource_group.test.location
+}
+`, data.RandomInteger, data.LocationPrimary, data.RandomString)
+}
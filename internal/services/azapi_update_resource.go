@@ -4,18 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/locks"
+	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/defaults"
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myplanmodifier"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/internal/waitfor"
 	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -39,10 +43,17 @@ type AzapiUpdateResourceModel struct {
 	IgnoreCasing          types.Bool     `tfsdk:"ignore_casing"`
 	IgnoreBodyChanges     types.List     `tfsdk:"ignore_body_changes"`
 	IgnoreMissingProperty types.Bool     `tfsdk:"ignore_missing_property"`
+	UpdateMethod          types.String   `tfsdk:"update_method"`
+	UpdateHeaders         types.Map      `tfsdk:"update_headers"`
+	UpdateQueryParameters types.Map      `tfsdk:"update_query_parameters"`
+	ReadHeaders           types.Map      `tfsdk:"read_headers"`
+	ReadQueryParameters   types.Map      `tfsdk:"read_query_parameters"`
 	ResponseExportValues  types.List     `tfsdk:"response_export_values"`
 	Locks                 types.List     `tfsdk:"locks"`
 	Output                types.String   `tfsdk:"output"`
 	OutputPayload         types.Dynamic  `tfsdk:"output_payload"`
+	Retry                 types.Object   `tfsdk:"retry"`
+	WaitFor               types.Object   `tfsdk:"wait_for"`
 	Timeouts              timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -158,11 +169,26 @@ func (r *AzapiUpdateResource) Schema(ctx context.Context, request resource.Schem
 				Default:  defaults.BoolDefault(true),
 			},
 
+			"update_method": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodPut, http.MethodPatch),
+				},
+			},
+
+			"update_headers": headersAttribute(),
+
+			"update_query_parameters": queryParametersAttribute(),
+
+			"read_headers": headersAttribute(),
+
+			"read_query_parameters": queryParametersAttribute(),
+
 			"response_export_values": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Validators: []validator.List{
-					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty()),
+					listvalidator.ValueStringsAre(myvalidator.StringIsNotEmpty(), myvalidator.StringIsExtractPath()),
 				},
 			},
 
@@ -185,6 +211,10 @@ func (r *AzapiUpdateResource) Schema(ctx context.Context, request resource.Schem
 		},
 
 		Blocks: map[string]schema.Block{
+			"retry": retry.Block(),
+
+			"wait_for": waitfor.Block(),
+
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
 				Read:   true,
@@ -302,7 +332,7 @@ func (r *AzapiUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan,
 	}
 
 	client := r.ProviderData.ResourceClient
-	existing, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	existing, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, model.readRequestOptions())
 	if err != nil {
 		diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("checking for presence of existing %s: %+v", id, err).Error())
 		return
@@ -332,7 +362,11 @@ func (r *AzapiUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan,
 		requestBody = map[string]interface{}{}
 	}
 
-	requestBody = utils.MergeObject(existing, requestBody)
+	// PATCH only sends the specified properties, PUT requires the whole resource body
+	isPatch := model.UpdateMethod.ValueString() == http.MethodPatch
+	if !isPatch {
+		requestBody = utils.MergeObject(existing, requestBody)
+	}
 	if ignoreChanges := AsStringList(model.IgnoreBodyChanges); len(ignoreChanges) != 0 {
 		out, err := overrideWithPaths(requestBody, existing, ignoreChanges)
 		if err != nil {
@@ -351,12 +385,34 @@ func (r *AzapiUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan,
 		defer locks.UnlockByID(id)
 	}
 
-	responseBody, err := client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, requestBody)
+	var responseBody interface{}
+	options := clients.RequestOptions{
+		Retry:           retry.ExpandRetry(model.Retry),
+		Headers:         expandHeaders(model.UpdateHeaders),
+		QueryParameters: expandQueryParameters(model.UpdateQueryParameters),
+	}
+	if isPatch {
+		responseBody, err = client.Patch(ctx, id.AzureResourceId, id.ApiVersion, requestBody, options)
+	} else {
+		responseBody, err = client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, requestBody, options)
+	}
 	if err != nil {
 		diagnostics.AddError("Failed to update resource", fmt.Errorf("updating %q: %+v", id, err).Error())
 		return
 	}
 
+	// wait until the condition in the `wait_for` block holds, the output is generated from the last response
+	// the resource has been updated even if the waiting fails, so it's still stored in the state. The failure of the
+	// first apply is reported as a warning, because Terraform would taint the resource and replace it in the next apply.
+	var waitErr error
+	if waitFor := waitfor.ExpandWaitFor(model.WaitFor); waitFor != nil {
+		if waitedBody, err := client.WaitFor(ctx, id.AzureResourceId, id.ApiVersion, waitFor, model.readRequestOptions()); err == nil {
+			responseBody = waitedBody
+		} else {
+			waitErr = err
+		}
+	}
+
 	model.ID = basetypes.NewStringValue(id.ID())
 	model.Name = basetypes.NewStringValue(id.Name)
 	model.ParentID = basetypes.NewStringValue(id.ParentId)
@@ -364,7 +420,21 @@ func (r *AzapiUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan,
 	model.Output = basetypes.NewStringValue(flattenOutput(responseBody, AsStringList(model.ResponseExportValues)))
 	model.OutputPayload = types.DynamicValue(flattenOutputPayload(responseBody, AsStringList(model.ResponseExportValues)))
 
+	isNewResource := state.Raw.IsNull()
 	diagnostics.Append(state.Set(ctx, model)...)
+	switch {
+	case waitErr != nil && isNewResource:
+		diagnostics.AddWarning("Failed to wait for resource", fmt.Sprintf("The resource %q has been updated, but the condition in the `wait_for` block doesn't hold: %+v", id, waitErr))
+	case waitErr != nil:
+		diagnostics.AddError("Failed to wait for resource", fmt.Errorf("waiting for %q: %+v", id, waitErr).Error())
+	}
+}
+
+func (m AzapiUpdateResourceModel) readRequestOptions() clients.RequestOptions {
+	return clients.RequestOptions{
+		Headers:         expandHeaders(m.ReadHeaders),
+		QueryParameters: expandQueryParameters(m.ReadQueryParameters),
+	}
 }
 
 func (r *AzapiUpdateResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
@@ -389,7 +459,7 @@ func (r *AzapiUpdateResource) Read(ctx context.Context, request resource.ReadReq
 	}
 
 	client := r.ProviderData.ResourceClient
-	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, model.readRequestOptions())
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			tflog.Info(ctx, fmt.Sprintf("[INFO] Error reading %q - removing from state", id.ID()))
@@ -470,5 +540,33 @@ func (r *AzapiUpdateResource) Read(ctx context.Context, request resource.ReadReq
 }
 
 func (r *AzapiUpdateResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var model AzapiUpdateResourceModel
+	if response.Diagnostics.Append(request.State.Get(ctx, &model)...); response.Diagnostics.HasError() {
+		return
+	}
 
+	// the resource isn't deleted, it only waits until the condition in the `wait_for` block holds if it's required
+	waitFor := waitfor.ExpandWaitFor(model.WaitFor)
+	if waitFor == nil || !waitFor.BeforeDelete {
+		return
+	}
+
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, 30*time.Minute)
+	if response.Diagnostics.Append(diags...); response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	id, err := parse.ResourceIDWithResourceType(model.ID.ValueString(), model.Type.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Invalid resource id", err.Error())
+		return
+	}
+
+	_, err = r.ProviderData.ResourceClient.WaitFor(ctx, id.AzureResourceId, id.ApiVersion, waitFor, model.readRequestOptions())
+	if err != nil && !utils.ResponseErrorWasNotFound(err) {
+		response.Diagnostics.AddError("Failed to wait for resource", fmt.Errorf("waiting for %q: %+v", id, err).Error())
+	}
 }
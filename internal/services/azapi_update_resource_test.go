@@ -33,6 +33,21 @@ func TestAccGenericUpdateResource_automationAccount(t *testing.T) {
 	})
 }
 
+func TestAccGenericUpdateResource_waitFor(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_update_resource", "test")
+	r := GenericUpdateResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.waitFor(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("output_payload.properties.publicNetworkAccess").HasValue("true"),
+			),
+		},
+	})
+}
+
 func TestAccGenericUpdateResource_dynamicSchema(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azapi_update_resource", "test")
 	r := GenericUpdateResource{}
@@ -129,6 +144,20 @@ func TestAccGenericUpdateResource_ignoreChangesArray(t *testing.T) {
 	})
 }
 
+func TestAccGenericUpdateResource_updateMethodPatch(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_update_resource", "test")
+	r := GenericUpdateResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.updateMethodPatch(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+	})
+}
+
 func (r GenericUpdateResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	resourceType := state.Attributes["type"]
 	id, err := parse.ResourceIDWithResourceType(state.ID, resourceType)
@@ -136,7 +165,7 @@ func (r GenericUpdateResource) Exists(ctx context.Context, client *clients.Clien
 		return nil, err
 	}
 
-	resp, err := client.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	resp, err := client.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOptions{})
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			exist := false
@@ -171,6 +200,36 @@ resource "azapi_update_resource" "test" {
 `, r.template(data), data.RandomStringOfLength(5))
 }
 
+func (r GenericUpdateResource) waitFor(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_automation_account" "test" {
+  name                = "acctest-%[2]s"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  sku_name            = "Basic"
+}
+
+resource "azapi_update_resource" "test" {
+  type        = "Microsoft.Automation/automationAccounts@2023-11-01"
+  resource_id = azurerm_automation_account.test.id
+  body = jsonencode({
+    properties = {
+      publicNetworkAccess = true
+    }
+  })
+
+  response_export_values = ["properties.publicNetworkAccess"]
+
+  wait_for {
+    path   = "properties.publicNetworkAccess"
+    values = ["true"]
+  }
+}
+`, r.template(data), data.RandomStringOfLength(5))
+}
+
 func (r GenericUpdateResource) dynamicSchema(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -372,6 +431,30 @@ resource "azapi_update_resource" "test" {
 `, r.template(data), data.RandomInt())
 }
 
+func (r GenericUpdateResource) updateMethodPatch(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_automation_account" "test" {
+  name                = "acctest-%[2]s"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  sku_name            = "Basic"
+}
+
+resource "azapi_update_resource" "test" {
+  type          = "Microsoft.Automation/automationAccounts@2023-11-01"
+  resource_id   = azurerm_automation_account.test.id
+  update_method = "PATCH"
+  body = jsonencode({
+    properties = {
+      publicNetworkAccess = false
+    }
+  })
+}
+`, r.template(data), data.RandomStringOfLength(5))
+}
+
 func (GenericUpdateResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 terraform {
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type BuildResourceIdFunction struct{}
+
+var _ function.Function = &BuildResourceIdFunction{}
+
+func (f *BuildResourceIdFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "build_resource_id"
+}
+
+func (f *BuildResourceIdFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "parent_id",
+				Description: "The ID of the azure resource in which the resource is created.",
+			},
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The azure resource type, for example, `Microsoft.Network/virtualNetworks/subnets`. It can also be in a format like `<resource-type>@<api-version>`.",
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "The name of the azure resource.",
+			},
+		},
+		Return:      function.StringReturn{},
+		Summary:     "Builds an Azure resource ID",
+		Description: "This function builds an Azure resource ID from the parent ID, resource type and name.",
+	}
+}
+
+func (f *BuildResourceIdFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var parentId, resourceType, name string
+	if response.Error = request.Arguments.Get(ctx, &parentId, &resourceType, &name); response.Error != nil {
+		return
+	}
+
+	id, err := parse.NewResourceID(name, parentId, resourceTypeWithApiVersion(resourceType))
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	response.Error = response.Result.Set(ctx, id.AzureResourceId)
+}
@@ -0,0 +1,4 @@
+package services
+
+// RemoveDataPlaneReadOnlyFields exposes removeDataPlaneReadOnlyFields to the tests.
+var RemoveDataPlaneReadOnlyFields = removeDataPlaneReadOnlyFields
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ExtensionResourceIdFunction struct{}
+
+var _ function.Function = &ExtensionResourceIdFunction{}
+
+func (f *ExtensionResourceIdFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "extension_resource_id"
+}
+
+func (f *ExtensionResourceIdFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base_resource_id",
+				Description: "The ID of the resource to which the extension resource is applied.",
+			},
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The azure resource type, for example, `Microsoft.Network/virtualNetworks/subnets`.",
+			},
+			function.ListParameter{
+				Name:        "resource_names",
+				ElementType: types.StringType,
+				Description: "The names of the resources, from the top level resource to the specified resource, for example, `[\"myVnet\", \"mySubnet\"]`.",
+			},
+		},
+		Return:      function.StringReturn{},
+		Summary:     "Builds the ID of an extension resource",
+		Description: "This function builds the ID of an extension resource from the ID of the resource to which the extension is applied, the resource type and resource names.",
+	}
+}
+
+func (f *ExtensionResourceIdFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var baseResourceId, resourceType string
+	var resourceNames []string
+	if response.Error = request.Arguments.Get(ctx, &baseResourceId, &resourceType, &resourceNames); response.Error != nil {
+		return
+	}
+
+	resourceId, err := buildScopedResourceID(baseResourceId, resourceType, resourceNames)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	response.Error = response.Result.Set(ctx, resourceId)
+}
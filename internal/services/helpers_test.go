@@ -0,0 +1,64 @@
+package services_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testResourceId = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Automation/automationAccounts/test"
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// testProviderData returns a client which sends the Azure Resource Manager requests to the test server.
+func testProviderData(t *testing.T, server *httptest.Server) *clients.Client {
+	client, err := clients.NewResourceClient(fakeCredential{}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {Endpoint: server.URL, Audience: "https://management.azure.com"},
+				},
+			},
+			Transport: server.Client(),
+			Retry:     policy.RetryOptions{MaxRetries: -1},
+		},
+		DisableRPRegistration: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &clients.Client{ResourceClient: client}
+}
+
+// testPlan returns a plan of the resource with the attributes set, the other attributes are null.
+func testPlan(t *testing.T, r resource.Resource, attributes map[string]attr.Value) tfsdk.Plan {
+	ctx := context.Background()
+	schemaResponse := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+	plan := tfsdk.Plan{
+		Schema: schemaResponse.Schema,
+		Raw:    tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range attributes {
+		if diags := plan.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("setting %s: %+v", name, diags)
+		}
+	}
+	return plan
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ManagementGroupResourceIdFunction struct{}
+
+var _ function.Function = &ManagementGroupResourceIdFunction{}
+
+func (f *ManagementGroupResourceIdFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "management_group_resource_id"
+}
+
+func (f *ManagementGroupResourceIdFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "management_group_name",
+				Description: "The name of the management group.",
+			},
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The azure resource type, for example, `Microsoft.Network/virtualNetworks/subnets`.",
+			},
+			function.ListParameter{
+				Name:        "resource_names",
+				ElementType: types.StringType,
+				Description: "The names of the resources, from the top level resource to the specified resource, for example, `[\"myVnet\", \"mySubnet\"]`.",
+			},
+		},
+		Return:      function.StringReturn{},
+		Summary:     "Builds the ID of a management group scope resource",
+		Description: "This function builds the ID of a resource deployed at the management group scope from the management group name, resource type and resource names.",
+	}
+}
+
+func (f *ManagementGroupResourceIdFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var managementGroupName, resourceType string
+	var resourceNames []string
+	if response.Error = request.Arguments.Get(ctx, &managementGroupName, &resourceType, &resourceNames); response.Error != nil {
+		return
+	}
+
+	resourceId, err := buildScopedResourceID(fmt.Sprintf("/providers/Microsoft.Management/managementGroups/%s", managementGroupName), resourceType, resourceNames)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	response.Error = response.Result.Set(ctx, resourceId)
+}
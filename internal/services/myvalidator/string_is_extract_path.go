@@ -0,0 +1,37 @@
+package myvalidator
+
+import (
+	"context"
+
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type stringIsExtractPath struct{}
+
+func (v stringIsExtractPath) Description(ctx context.Context) string {
+	return "validate this is a valid path of the response body"
+}
+
+func (v stringIsExtractPath) MarkdownDescription(ctx context.Context) string {
+	return "validate this is a valid path of the response body"
+}
+
+func (_ stringIsExtractPath) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	str := req.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	if err := utils.ValidateExtractPath(str.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid path",
+			err.Error())
+	}
+}
+
+func StringIsExtractPath() stringIsExtractPath {
+	return stringIsExtractPath{}
+}
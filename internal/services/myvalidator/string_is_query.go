@@ -0,0 +1,37 @@
+package myvalidator
+
+import (
+	"context"
+
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type stringIsQuery struct{}
+
+func (v stringIsQuery) Description(ctx context.Context) string {
+	return "validate this is a valid JMESPath expression"
+}
+
+func (v stringIsQuery) MarkdownDescription(ctx context.Context) string {
+	return "validate this is a valid JMESPath expression"
+}
+
+func (_ stringIsQuery) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	str := req.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	if err := utils.ValidateQuery(str.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid query expression",
+			err.Error())
+	}
+}
+
+func StringIsQuery() stringIsQuery {
+	return stringIsQuery{}
+}
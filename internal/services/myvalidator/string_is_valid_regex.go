@@ -0,0 +1,37 @@
+package myvalidator
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type stringIsValidRegex struct{}
+
+func (v stringIsValidRegex) Description(ctx context.Context) string {
+	return "validate this is a valid regular expression"
+}
+
+func (v stringIsValidRegex) MarkdownDescription(ctx context.Context) string {
+	return "validate this is a valid regular expression"
+}
+
+func (_ stringIsValidRegex) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	str := req.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	if _, err := regexp.Compile(str.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid regular expression",
+			err.Error())
+	}
+}
+
+func StringIsValidRegex() stringIsValidRegex {
+	return stringIsValidRegex{}
+}
@@ -2,7 +2,10 @@ package parse
 
 import (
 	"encoding/json"
+	"fmt"
+	"os"
 	"strings"
+	"sync"
 )
 
 type ApiPath struct {
@@ -10,10 +13,18 @@ type ApiPath struct {
 	ResourceType    string
 	URL             string
 	ParentIDExample string
+	// Audience is the audience of the access token used to call the data plane API, it's optional and if not specified,
+	// the audience is determined by the host of the request.
+	Audience string
 }
 
 var apiPaths = make([]ApiPath, 0)
 
+// customApiPaths are the data plane resource types defined by the user, they take precedence over the built-in ones.
+var customApiPaths = make(map[string]ApiPath)
+
+var customApiPathsMux = &sync.RWMutex{}
+
 func init() {
 	err := json.Unmarshal([]byte(raw), &apiPaths)
 	if err != nil {
@@ -22,6 +33,12 @@ func init() {
 }
 
 func findApiPathByResourceType(resourceType string) *ApiPath {
+	customApiPathsMux.RLock()
+	apiPath, ok := customApiPaths[strings.ToLower(resourceType)]
+	customApiPathsMux.RUnlock()
+	if ok {
+		return &apiPath
+	}
 	for _, apiPath := range apiPaths {
 		if strings.EqualFold(apiPath.ResourceType, resourceType) {
 			return &apiPath
@@ -30,6 +47,68 @@ func findApiPathByResourceType(resourceType string) *ApiPath {
 	return nil
 }
 
+// DataPlaneAudience returns the audience of the access token used to call the data plane API of the resource type,
+// it returns an empty string if the audience is not specified.
+func DataPlaneAudience(resourceType string) string {
+	if apiPath := findApiPathByResourceType(resourceType); apiPath != nil {
+		return apiPath.Audience
+	}
+	return ""
+}
+
+// LoadDataPlaneTypeDefinitions loads the data plane resource type definitions from a file path or an inline JSON string.
+// The input is a JSON array, each item contains `UrlFormat`, `ResourceType`, `ParentIDExample` and an optional `Audience`.
+// The loaded definitions replace the ones loaded before, an empty input removes them.
+func LoadDataPlaneTypeDefinitions(input string) error {
+	data := []byte(strings.TrimSpace(input))
+	if len(data) == 0 {
+		data = []byte("[]")
+	}
+	if !strings.HasPrefix(string(data), "[") {
+		// #nosec G304
+		content, err := os.ReadFile(input)
+		if err != nil {
+			return fmt.Errorf("reading data plane type definitions from file %q: %+v", input, err)
+		}
+		data = content
+	}
+
+	var definitions []ApiPath
+	if err := json.Unmarshal(data, &definitions); err != nil {
+		return fmt.Errorf("unmarshalling data plane type definitions: %+v", err)
+	}
+	for i, definition := range definitions {
+		if definition.ResourceType == "" {
+			return fmt.Errorf("the `ResourceType` of data plane type definition %d is not specified", i)
+		}
+		if !hasUrlFormatSegment(definition.UrlFormat, "{parentId}") || !hasUrlFormatSegment(definition.UrlFormat, "{name}") {
+			return fmt.Errorf("the `UrlFormat` of data plane type definition %q must contain `{parentId}` and `{name}` (or `{name=<fixed name>}`) segments", definition.ResourceType)
+		}
+	}
+
+	loaded := make(map[string]ApiPath)
+	for _, definition := range definitions {
+		loaded[strings.ToLower(definition.ResourceType)] = definition
+	}
+	customApiPathsMux.Lock()
+	defer customApiPathsMux.Unlock()
+	customApiPaths = loaded
+	return nil
+}
+
+// hasUrlFormatSegment returns true if the URL format contains the placeholder as a path segment, `{name}` also matches `{name=<fixed name>}`.
+func hasUrlFormatSegment(urlFormat string, placeholder string) bool {
+	for _, part := range strings.Split(urlFormat, "/") {
+		if part == placeholder {
+			return true
+		}
+		if placeholder == "{name}" && strings.HasPrefix(part, "{name=") && strings.HasSuffix(part, "}") {
+			return true
+		}
+	}
+	return false
+}
+
 const raw = `
 [
   {
@@ -0,0 +1,150 @@
+package parse_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+)
+
+func Test_LoadDataPlaneTypeDefinitions(t *testing.T) {
+	t.Cleanup(parse.ResetDataPlaneTypeDefinitions)
+
+	filePath := filepath.Join(t.TempDir(), "definitions.json")
+	fileContent := `[
+  {
+    "UrlFormat": "{parentId}/widgets/{name}",
+    "ResourceType": "Contoso.Widgets/accounts/widgets",
+    "ParentIDExample": "{accountName}.widgets.contoso.com",
+    "Audience": "https://widgets.contoso.com"
+  }
+]`
+	if err := os.WriteFile(filePath, []byte(fileContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		Input            string
+		Error            bool
+		ResourceType     string
+		Name             string
+		ParentId         string
+		ExpectedId       string
+		ExpectedAudience string
+	}{
+		{
+			Input:            filePath,
+			ResourceType:     "Contoso.Widgets/accounts/widgets@2024-01-01",
+			Name:             "mywidget",
+			ParentId:         "myaccount.widgets.contoso.com",
+			ExpectedId:       "myaccount.widgets.contoso.com/widgets/mywidget",
+			ExpectedAudience: "https://widgets.contoso.com",
+		},
+		{
+			Input:        `[{"UrlFormat": "{parentId}/gadgets/{name}", "ResourceType": "Contoso.Gadgets/accounts/gadgets"}]`,
+			ResourceType: "Contoso.Gadgets/accounts/gadgets@2024-01-01",
+			Name:         "mygadget",
+			ParentId:     "myaccount.gadgets.contoso.com",
+			ExpectedId:   "myaccount.gadgets.contoso.com/gadgets/mygadget",
+		},
+		{
+			// the custom definition takes precedence over the built-in one
+			Input:            `[{"UrlFormat": "{parentId}/kv/{name}", "ResourceType": "Microsoft.AppConfiguration/configurationStores/keyValues", "Audience": "https://azconfig.io"}]`,
+			ResourceType:     "Microsoft.AppConfiguration/configurationStores/keyValues@1.0",
+			Name:             "mykey",
+			ParentId:         "mystore.azconfig.io",
+			ExpectedId:       "mystore.azconfig.io/kv/mykey",
+			ExpectedAudience: "https://azconfig.io",
+		},
+		{
+			Input:        `[{"UrlFormat": "{parentId}/settings/{name=default}", "ResourceType": "Contoso.Gadgets/accounts/settings"}]`,
+			ResourceType: "Contoso.Gadgets/accounts/settings@2024-01-01",
+			Name:         "default",
+			ParentId:     "myaccount.gadgets.contoso.com",
+			ExpectedId:   "myaccount.gadgets.contoso.com/settings/default",
+		},
+		{
+			Input: `[{"UrlFormat": "{parentId}/gadgets", "ResourceType": "Contoso.Gadgets/accounts/others"}]`,
+			Error: true,
+		},
+		{
+			Input: `[{"UrlFormat": "{parentId}/gadgets/{names}", "ResourceType": "Contoso.Gadgets/accounts/others"}]`,
+			Error: true,
+		},
+		{
+			Input: `[{"UrlFormat": "{parentId}/gadgets/{name}"}]`,
+			Error: true,
+		},
+		{
+			Input: filepath.Join(t.TempDir(), "not-exist.json"),
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		err := parse.LoadDataPlaneTypeDefinitions(v.Input)
+		if v.Error {
+			if err == nil {
+				t.Fatalf("expect error but got nil")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expect no error but got %+v", err)
+		}
+
+		id, err := parse.NewDataPlaneResourceId(v.Name, v.ParentId, v.ResourceType)
+		if err != nil {
+			t.Fatalf("expect no error but got %+v", err)
+		}
+		if id.ID() != v.ExpectedId {
+			t.Fatalf("expect id %q but got %q", v.ExpectedId, id.ID())
+		}
+		if audience := parse.DataPlaneAudience(id.AzureResourceType); audience != v.ExpectedAudience {
+			t.Fatalf("expect audience %q but got %q", v.ExpectedAudience, audience)
+		}
+	}
+}
+
+func Test_LoadDataPlaneTypeDefinitionsReplacesLoaded(t *testing.T) {
+	t.Cleanup(parse.ResetDataPlaneTypeDefinitions)
+
+	widgetId := func() string {
+		id, err := parse.NewDataPlaneResourceId("mywidget", "myaccount.widgets.contoso.com", "Contoso.Widgets/accounts/widgets@2024-01-01")
+		if err != nil {
+			t.Fatalf("expect no error but got %+v", err)
+		}
+		return id.ID()
+	}
+
+	if err := parse.LoadDataPlaneTypeDefinitions(`[{"UrlFormat": "{parentId}/widgets/{name}", "ResourceType": "Contoso.Widgets/accounts/widgets"}]`); err != nil {
+		t.Fatalf("expect no error but got %+v", err)
+	}
+	if id := widgetId(); id != "myaccount.widgets.contoso.com/widgets/mywidget" {
+		t.Fatalf("expect the loaded definition to be used but got id %q", id)
+	}
+
+	if err := parse.LoadDataPlaneTypeDefinitions(`[{"UrlFormat": "{parentId}/gadgets/{name}", "ResourceType": "Contoso.Gadgets/accounts/gadgets"}]`); err != nil {
+		t.Fatalf("expect no error but got %+v", err)
+	}
+	if id := widgetId(); id != "" {
+		t.Fatalf("expect the definition loaded before to be removed but got id %q", id)
+	}
+
+	if err := parse.LoadDataPlaneTypeDefinitions(""); err != nil {
+		t.Fatalf("expect no error but got %+v", err)
+	}
+	if audience := parse.DataPlaneAudience("Contoso.Gadgets/accounts/gadgets"); audience != "" {
+		t.Fatalf("expect no audience but got %q", audience)
+	}
+	id, err := parse.NewDataPlaneResourceId("mygadget", "myaccount.gadgets.contoso.com", "Contoso.Gadgets/accounts/gadgets@2024-01-01")
+	if err != nil {
+		t.Fatalf("expect no error but got %+v", err)
+	}
+	if id.ID() != "" {
+		t.Fatalf("expect the definitions to be removed by an empty input but got id %q", id.ID())
+	}
+}
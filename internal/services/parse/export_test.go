@@ -0,0 +1,8 @@
+package parse
+
+// ResetDataPlaneTypeDefinitions removes the data plane type definitions loaded by the tests.
+func ResetDataPlaneTypeDefinitions() {
+	customApiPathsMux.Lock()
+	defer customApiPathsMux.Unlock()
+	customApiPaths = make(map[string]ApiPath)
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+type ParseResourceIdFunction struct{}
+
+var _ function.Function = &ParseResourceIdFunction{}
+
+var parseResourceIdResultAttrTypes = map[string]attr.Type{
+	"id":                  types.StringType,
+	"type":                types.StringType,
+	"name":                types.StringType,
+	"parent_id":           types.StringType,
+	"resource_group_name": types.StringType,
+	"subscription_id":     types.StringType,
+	"provider_namespace":  types.StringType,
+	"parts":               types.MapType{ElemType: types.StringType},
+}
+
+func (f *ParseResourceIdFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "parse_resource_id"
+}
+
+func (f *ParseResourceIdFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The azure resource type, for example, `Microsoft.Network/virtualNetworks/subnets`. It can also be in a format like `<resource-type>@<api-version>`.",
+			},
+			function.StringParameter{
+				Name:        "resource_id",
+				Description: "The ID of the azure resource.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parseResourceIdResultAttrTypes,
+		},
+		Summary:     "Parses an Azure resource ID",
+		Description: "This function parses an Azure resource ID into an object which contains the `id`, `type`, `name`, `parent_id`, `resource_group_name`, `subscription_id`, `provider_namespace` and `parts`.",
+	}
+}
+
+func (f *ParseResourceIdFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var resourceType, resourceId string
+	if response.Error = request.Arguments.Get(ctx, &resourceType, &resourceId); response.Error != nil {
+		return
+	}
+
+	id, err := parse.ResourceIDWithResourceType(resourceId, resourceTypeWithApiVersion(resourceType))
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	armId, err := armResourceID(id.AzureResourceId)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	result, diags := basetypes.NewObjectValue(parseResourceIdResultAttrTypes, map[string]attr.Value{
+		"id":                  basetypes.NewStringValue(id.AzureResourceId),
+		"type":                basetypes.NewStringValue(id.AzureResourceType),
+		"name":                basetypes.NewStringValue(id.Name),
+		"parent_id":           basetypes.NewStringValue(id.ParentId),
+		"resource_group_name": basetypes.NewStringValue(armId.ResourceGroupName),
+		"subscription_id":     basetypes.NewStringValue(armId.SubscriptionID),
+		"provider_namespace":  basetypes.NewStringValue(armId.ResourceType.Namespace),
+		"parts":               resourceIdParts(id.AzureResourceId),
+	})
+	if diags.HasError() {
+		response.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	response.Error = response.Result.Set(ctx, result)
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/Azure/terraform-provider-azapi/internal/azure"
@@ -12,6 +14,8 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
 	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -47,6 +51,95 @@ func schemaValidation(azureResourceType, apiVersion string, resourceDef *aztypes
 	return nil
 }
 
+// actionSchemaValidation validates the action and its request body with the embedded resource functions.
+// The embedded schema only contains the `list*` functions, so the other actions are not validated.
+func actionSchemaValidation(azureResourceType, apiVersion, actionName, method string, body interface{}) error {
+	if actionName == "" || method != "POST" {
+		return nil
+	}
+	log.Printf("[INFO] prepare validation for action: %s, resource type: %s, api-version: %s", actionName, azureResourceType, apiVersion)
+	if versions := azure.GetApiVersions(azureResourceType); len(versions) != 0 {
+		isVersionValid := false
+		for _, version := range versions {
+			if version == apiVersion {
+				isVersionValid = true
+				break
+			}
+		}
+		if !isVersionValid {
+			return schemaValidationError(fmt.Sprintf("the argument \"type\"'s api-version is invalid.\n The supported versions are [%s].\n", strings.Join(versions, ", ")))
+		}
+	}
+
+	functionDefs, err := azure.GetResourceFunctionDefinitions(azureResourceType, apiVersion)
+	if err != nil || len(functionDefs) == 0 {
+		return nil
+	}
+
+	var functionDef *aztypes.ResourceFunctionType
+	supportedActions := make([]string, 0)
+	for _, def := range functionDefs {
+		if strings.EqualFold(def.Name, actionName) {
+			functionDef = def
+			break
+		}
+		supportedActions = append(supportedActions, def.Name)
+	}
+	if functionDef == nil {
+		if strings.HasPrefix(strings.ToLower(actionName), "list") {
+			sort.Strings(supportedActions)
+			return schemaValidationError(fmt.Sprintf("the argument \"action\" is invalid.\n The supported list actions are [%s].\n", strings.Join(supportedActions, ", ")))
+		}
+		return nil
+	}
+
+	if functionDef.Input != nil && functionDef.Input.Type != nil {
+		errors := (*functionDef.Input.Type).Validate(utils.NormalizeObject(body), "")
+		if len(errors) != 0 {
+			errorMsg := "the argument \"body\" is invalid:\n"
+			for _, err := range errors {
+				errorMsg += fmt.Sprintf("%s\n", err.Error())
+			}
+			return schemaValidationError(errorMsg)
+		}
+	}
+	return nil
+}
+
+// dataPlaneSchemaValidation validates the data plane resource body with the embedded data plane schema.
+// Only some data plane resource types have embedded definitions, the others are not validated.
+func dataPlaneSchemaValidation(azureResourceType, apiVersion string, body interface{}) error {
+	versions := azure.GetDataPlaneApiVersions(azureResourceType)
+	if len(versions) == 0 {
+		return nil
+	}
+	log.Printf("[INFO] prepare validation for data plane resource type: %s, api-version: %s", azureResourceType, apiVersion)
+	isVersionValid := false
+	for _, version := range versions {
+		if version == apiVersion {
+			isVersionValid = true
+			break
+		}
+	}
+	if !isVersionValid {
+		return schemaValidationError(fmt.Sprintf("the argument \"type\"'s api-version is invalid.\n The supported versions are [%s].\n", strings.Join(versions, ", ")))
+	}
+
+	resourceDef, err := azure.GetDataPlaneResourceDefinition(azureResourceType, apiVersion)
+	if err != nil || resourceDef == nil {
+		return nil
+	}
+	errors := (*resourceDef).Validate(utils.NormalizeObject(body), "")
+	if len(errors) != 0 {
+		errorMsg := "the argument \"body\" is invalid:\n"
+		for _, err := range errors {
+			errorMsg += fmt.Sprintf("%s\n", err.Error())
+		}
+		return schemaValidationError(errorMsg)
+	}
+	return nil
+}
+
 func schemaValidationError(detail string) error {
 	return fmt.Errorf("embedded schema validation failed: %s You can try to update `azapi` provider to "+
 		"the latest version or disable the validation using the feature flag `schema_validation_enabled = false` "+
@@ -69,6 +162,20 @@ func canResourceHaveProperty(resourceDef *aztypes.ResourceType, property string)
 	return false
 }
 
+// splitSensitiveOutput returns the response body whose sensitive values are redacted and the sensitive values,
+// the sensitive properties are defined in the embedded schema. The sensitive values are nil if there's no sensitive value
+// or the resource type isn't defined in the embedded schema.
+func splitSensitiveOutput(resourceDef *aztypes.ResourceType, responseBody interface{}) (interface{}, interface{}) {
+	if resourceDef == nil {
+		return responseBody, nil
+	}
+	sensitive := resourceDef.GetSensitive(utils.NormalizeObject(responseBody))
+	if sensitive == nil {
+		return responseBody, nil
+	}
+	return utils.RedactObject(responseBody, sensitive), sensitive
+}
+
 func overrideWithPaths(base interface{}, changed interface{}, paths []string) (interface{}, error) {
 	if len(paths) == 0 {
 		return base, nil
@@ -100,7 +207,7 @@ func flattenOutput(responseBody interface{}, paths []string) string {
 		if part == nil {
 			continue
 		}
-		output = utils.MergeObject(output, part)
+		output = utils.MergeExtractedObject(output, part)
 	}
 	outputJson, _ := json.Marshal(output)
 	return string(outputJson)
@@ -131,7 +238,7 @@ func flattenOutputPayload(responseBody interface{}, paths []string) attr.Value {
 		if part == nil {
 			continue
 		}
-		output = utils.MergeObject(output, part)
+		output = utils.MergeExtractedObject(output, part)
 	}
 	data, err := json.Marshal(output)
 	if err != nil {
@@ -144,6 +251,52 @@ func flattenOutputPayload(responseBody interface{}, paths []string) attr.Value {
 	return out
 }
 
+// queryParametersAttribute returns the schema of the query parameters, the key is the parameter name and the value is a list of values.
+func queryParametersAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		ElementType: types.ListType{ElemType: types.StringType},
+		Optional:    true,
+	}
+}
+
+func expandQueryParameters(input types.Map) url.Values {
+	if input.IsNull() || input.IsUnknown() {
+		return nil
+	}
+	out := url.Values{}
+	for key, element := range input.Elements() {
+		list, ok := element.(types.List)
+		if !ok {
+			continue
+		}
+		for _, value := range AsStringList(list) {
+			out.Add(key, value)
+		}
+	}
+	return out
+}
+
+// headersAttribute returns the schema of the request headers, the key is the header name and the value is the header value.
+func headersAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+}
+
+func expandHeaders(input types.Map) map[string]string {
+	if input.IsNull() || input.IsUnknown() {
+		return nil
+	}
+	out := make(map[string]string)
+	for key, element := range input.Elements() {
+		if v, ok := element.(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			out[key] = v.ValueString()
+		}
+	}
+	return out
+}
+
 func AsStringList(input types.List) []string {
 	var result []string
 	diags := input.ElementsAs(context.Background(), &result, false)
@@ -152,3 +305,42 @@ func AsStringList(input types.List) []string {
 	}
 	return result
 }
+
+// pendingOperationKey is the key of the private state which stores the long-running operation that was interrupted in the last apply
+const pendingOperationKey = "pending_operation"
+
+// privateState is implemented by the private state data of the resource requests and responses
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+type pendingOperation struct {
+	ResumeToken string `json:"resume_token"`
+}
+
+// getPendingOperation returns the resume token of the long-running operation which was interrupted in the last apply
+func getPendingOperation(ctx context.Context, private privateState) (string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, pendingOperationKey)
+	if diags.HasError() || len(value) == 0 {
+		return "", diags
+	}
+	var operation pendingOperation
+	if err := json.Unmarshal(value, &operation); err != nil {
+		diags.AddError("Invalid private state", fmt.Sprintf("decoding the pending operation: %+v", err))
+		return "", diags
+	}
+	return operation.ResumeToken, diags
+}
+
+// setPendingOperation stores the resume token of the long-running operation, it removes the pending operation if the token is empty
+func setPendingOperation(ctx context.Context, private privateState, resumeToken string) diag.Diagnostics {
+	if resumeToken == "" {
+		return private.SetKey(ctx, pendingOperationKey, nil)
+	}
+	value, err := json.Marshal(pendingOperation{ResumeToken: resumeToken})
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Invalid private state", fmt.Sprintf("encoding the pending operation: %+v", err))}
+	}
+	return private.SetKey(ctx, pendingOperationKey, value)
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// resourceTypeWithApiVersion appends a placeholder api-version to the resource type if it's not specified,
+// the api-version is not used to build or parse the resource ID.
+func resourceTypeWithApiVersion(resourceType string) string {
+	if strings.Contains(resourceType, "@") {
+		return resourceType
+	}
+	return resourceType + "@latest"
+}
+
+// buildScopedResourceID builds the ID of a resource under the scope. The resource names are used in order,
+// from the top level resource type to the specified resource type.
+func buildScopedResourceID(scopeId, resourceType string, resourceNames []string) (string, error) {
+	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(resourceTypeWithApiVersion(resourceType))
+	if err != nil {
+		return "", err
+	}
+	segments := strings.Split(azureResourceType, "/")
+	if len(segments) < 2 {
+		return "", fmt.Errorf("the resource type %s is invalid, it should be like `Microsoft.Network/virtualNetworks/subnets`", azureResourceType)
+	}
+	if len(segments)-1 != len(resourceNames) {
+		return "", fmt.Errorf("the resource type %s expects %d resource names, but got %d", azureResourceType, len(segments)-1, len(resourceNames))
+	}
+
+	azureResourceId := scopeId
+	for i, name := range resourceNames {
+		if name == "" {
+			return "", fmt.Errorf("the resource name at index %d is empty", i)
+		}
+		id, err := parse.NewResourceIDSkipScopeValidation(name, azureResourceId, fmt.Sprintf("%s@%s", strings.Join(segments[:i+2], "/"), apiVersion))
+		if err != nil {
+			return "", err
+		}
+		azureResourceId = id.AzureResourceId
+	}
+	return azureResourceId, nil
+}
+
+// armResourceID parses the resource ID with the ARM SDK, it also supports the tenant ID `/`.
+func armResourceID(azureResourceId string) (*arm.ResourceID, error) {
+	if azureResourceId == "/" {
+		return &arm.ResourceID{
+			ResourceType: arm.TenantResourceType,
+		}, nil
+	}
+	return arm.ParseResourceID(azureResourceId)
+}
+
+// resourceIdParts splits the resource ID into a map whose keys are the resource types and values are the resource names.
+func resourceIdParts(azureResourceId string) types.Map {
+	path := azureResourceId
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimSuffix(path, "/")
+	components := strings.Split(path, "/")
+	parts := make(map[string]attr.Value)
+	for i := 0; i < len(components)-1; i += 2 {
+		parts[components[i]] = basetypes.NewStringValue(components[i+1])
+	}
+	return basetypes.NewMapValueMust(types.StringType, parts)
+}
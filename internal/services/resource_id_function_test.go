@@ -0,0 +1,130 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type ResourceIdFunction struct{}
+
+func TestAccResourceIdFunction_buildResourceId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource_id", "test")
+	r := ResourceIdFunction{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.buildResourceId(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckOutput("resource_group_id", "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroupName"),
+				resource.TestCheckOutput("subnet_id", "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroupName/providers/Microsoft.Network/virtualNetworks/vnetName/subnets/subnetName"),
+			),
+		},
+	})
+}
+
+func TestAccResourceIdFunction_parseResourceId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource_id", "test")
+	r := ResourceIdFunction{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.parseResourceId(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckOutput("name", "subnetName"),
+				resource.TestCheckOutput("parent_id", "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroupName/providers/Microsoft.Network/virtualNetworks/vnetName"),
+				resource.TestCheckOutput("resource_group_name", "resourceGroupName"),
+				resource.TestCheckOutput("subscription_id", "00000000-0000-0000-0000-000000000000"),
+				resource.TestCheckOutput("provider_namespace", "Microsoft.Network"),
+				resource.TestCheckOutput("virtual_network_name", "vnetName"),
+			),
+		},
+	})
+}
+
+func TestAccResourceIdFunction_scopedResourceId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource_id", "test")
+	r := ResourceIdFunction{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.scopedResourceId(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckOutput("tenant_resource_id", "/providers/Microsoft.Management/managementGroups/mgName"),
+				resource.TestCheckOutput("subscription_resource_id", "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroupName"),
+				resource.TestCheckOutput("management_group_resource_id", "/providers/Microsoft.Management/managementGroups/mgName/providers/Microsoft.Authorization/policyDefinitions/policyName"),
+				resource.TestCheckOutput("resource_group_resource_id", "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroupName/providers/Microsoft.Network/virtualNetworks/vnetName/subnets/subnetName"),
+				resource.TestCheckOutput("extension_resource_id", "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroupName/providers/Microsoft.Authorization/locks/lockName"),
+			),
+		},
+	})
+}
+
+func (r ResourceIdFunction) buildResourceId() string {
+	return `
+output "resource_group_id" {
+  value = provider::azapi::build_resource_id("/subscriptions/00000000-0000-0000-0000-000000000000", "Microsoft.Resources/resourceGroups", "resourceGroupName")
+}
+
+output "subnet_id" {
+  value = provider::azapi::build_resource_id("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroupName/providers/Microsoft.Network/virtualNetworks/vnetName", "Microsoft.Network/virtualNetworks/subnets@2023-04-01", "subnetName")
+}
+`
+}
+
+func (r ResourceIdFunction) parseResourceId() string {
+	return `
+locals {
+  subnet = provider::azapi::parse_resource_id("Microsoft.Network/virtualNetworks/subnets", "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroupName/providers/Microsoft.Network/virtualNetworks/vnetName/subnets/subnetName")
+}
+
+output "name" {
+  value = local.subnet.name
+}
+
+output "parent_id" {
+  value = local.subnet.parent_id
+}
+
+output "resource_group_name" {
+  value = local.subnet.resource_group_name
+}
+
+output "subscription_id" {
+  value = local.subnet.subscription_id
+}
+
+output "provider_namespace" {
+  value = local.subnet.provider_namespace
+}
+
+output "virtual_network_name" {
+  value = local.subnet.parts.virtualNetworks
+}
+`
+}
+
+func (r ResourceIdFunction) scopedResourceId() string {
+	return `
+output "tenant_resource_id" {
+  value = provider::azapi::tenant_resource_id("Microsoft.Management/managementGroups", ["mgName"])
+}
+
+output "subscription_resource_id" {
+  value = provider::azapi::subscription_resource_id("00000000-0000-0000-0000-000000000000", "Microsoft.Resources/resourceGroups", ["resourceGroupName"])
+}
+
+output "management_group_resource_id" {
+  value = provider::azapi::management_group_resource_id("mgName", "Microsoft.Authorization/policyDefinitions", ["policyName"])
+}
+
+output "resource_group_resource_id" {
+  value = provider::azapi::resource_group_resource_id("00000000-0000-0000-0000-000000000000", "resourceGroupName", "Microsoft.Network/virtualNetworks/subnets", ["vnetName", "subnetName"])
+}
+
+output "extension_resource_id" {
+  value = provider::azapi::extension_resource_id("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroupName", "Microsoft.Authorization/locks", ["lockName"])
+}
+`
+}
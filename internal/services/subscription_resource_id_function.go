@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SubscriptionResourceIdFunction struct{}
+
+var _ function.Function = &SubscriptionResourceIdFunction{}
+
+func (f *SubscriptionResourceIdFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "subscription_resource_id"
+}
+
+func (f *SubscriptionResourceIdFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "subscription_id",
+				Description: "The ID of the subscription, for example, `00000000-0000-0000-0000-000000000000`.",
+			},
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The azure resource type, for example, `Microsoft.Network/virtualNetworks/subnets`.",
+			},
+			function.ListParameter{
+				Name:        "resource_names",
+				ElementType: types.StringType,
+				Description: "The names of the resources, from the top level resource to the specified resource, for example, `[\"myVnet\", \"mySubnet\"]`.",
+			},
+		},
+		Return:      function.StringReturn{},
+		Summary:     "Builds the ID of a subscription scope resource",
+		Description: "This function builds the ID of a resource deployed at the subscription scope from the subscription ID, resource type and resource names.",
+	}
+}
+
+func (f *SubscriptionResourceIdFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var subscriptionId, resourceType string
+	var resourceNames []string
+	if response.Error = request.Arguments.Get(ctx, &subscriptionId, &resourceType, &resourceNames); response.Error != nil {
+		return
+	}
+
+	resourceId, err := buildScopedResourceID(fmt.Sprintf("/subscriptions/%s", subscriptionId), resourceType, resourceNames)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	response.Error = response.Result.Set(ctx, resourceId)
+}
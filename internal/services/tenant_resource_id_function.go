@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type TenantResourceIdFunction struct{}
+
+var _ function.Function = &TenantResourceIdFunction{}
+
+func (f *TenantResourceIdFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "tenant_resource_id"
+}
+
+func (f *TenantResourceIdFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "resource_type",
+				Description: "The azure resource type, for example, `Microsoft.Network/virtualNetworks/subnets`.",
+			},
+			function.ListParameter{
+				Name:        "resource_names",
+				ElementType: types.StringType,
+				Description: "The names of the resources, from the top level resource to the specified resource, for example, `[\"myVnet\", \"mySubnet\"]`.",
+			},
+		},
+		Return:      function.StringReturn{},
+		Summary:     "Builds the ID of a tenant scope resource",
+		Description: "This function builds the ID of a resource deployed at the tenant scope from the resource type and resource names.",
+	}
+}
+
+func (f *TenantResourceIdFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var resourceType string
+	var resourceNames []string
+	if response.Error = request.Arguments.Get(ctx, &resourceType, &resourceNames); response.Error != nil {
+		return
+	}
+
+	resourceId, err := buildScopedResourceID("/", resourceType, resourceNames)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	response.Error = response.Result.Set(ctx, resourceId)
+}
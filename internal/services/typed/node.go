@@ -0,0 +1,297 @@
+package typed
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+// maxDepth is the maximum depth of the nested attributes, the deeper properties are represented as JSON strings.
+const maxDepth = 8
+
+type nodeKind int
+
+const (
+	kindString nodeKind = iota
+	kindInt
+	kindBool
+	kindObject
+	kindList
+	kindMap
+	// kindJSON is used for the types that can't be represented by the framework types, e.g. any type or union of different types.
+	// The value is a JSON string.
+	kindJSON
+)
+
+// Node is the intermediate representation of an Azure type, it's used to build the framework schema and to convert the values.
+type Node struct {
+	kind nodeKind
+
+	// JsonName is the property name in the request and response body, TfName is the attribute name in the Terraform schema.
+	JsonName string
+	TfName   string
+
+	Required     bool
+	ComputedOnly bool
+	Sensitive    bool
+	// RequiresReplace is true if the property is a deploy-time constant.
+	RequiresReplace bool
+	Description     string
+
+	// Attributes are the children of an object node, ordered by TfName.
+	Attributes []*Node
+	// Element is the element of a list or map node.
+	Element *Node
+
+	Pattern   string
+	OneOf     []string
+	MinLength *int
+	MaxLength *int
+	MinValue  *int
+	MaxValue  *int
+}
+
+// Attribute returns the child attribute with the given Terraform name.
+func (n *Node) Attribute(tfName string) *Node {
+	for _, attr := range n.Attributes {
+		if attr.TfName == tfName {
+			return attr
+		}
+	}
+	return nil
+}
+
+// NewBodyNode builds the node of the resource body, the `id`, `type`, `apiVersion` properties and the properties that can't be
+// mapped to a valid Terraform attribute name are excluded.
+func NewBodyNode(resourceDef *types.ResourceType) (*Node, error) {
+	if resourceDef == nil || resourceDef.Body == nil || resourceDef.Body.Type == nil {
+		return nil, fmt.Errorf("the resource body is not defined")
+	}
+	node := newNode(resourceDef.Body.Type, 0, map[*types.TypeBase]bool{})
+	if node.kind != kindObject {
+		return nil, fmt.Errorf("the resource body is not an object")
+	}
+	attributes := make([]*Node, 0)
+	for _, attr := range node.Attributes {
+		switch attr.JsonName {
+		case "id", "type", "apiVersion":
+			continue
+		}
+		if reservedNames[attr.TfName] {
+			continue
+		}
+		attributes = append(attributes, attr)
+	}
+	node.Attributes = attributes
+	return node, nil
+}
+
+// reservedNames are the attribute names which are reserved by Terraform or used by the typed resource itself.
+var reservedNames = map[string]bool{
+	"count":       true,
+	"depends_on":  true,
+	"for_each":    true,
+	"lifecycle":   true,
+	"provider":    true,
+	"provisioner": true,
+	"connection":  true,
+	"parent_id":   true,
+	"timeouts":    true,
+}
+
+// newNode builds the node of the type, visiting contains the types of the ancestors, the recursive types are represented as JSON strings.
+func newNode(typeBase *types.TypeBase, depth int, visiting map[*types.TypeBase]bool) *Node {
+	if typeBase == nil || *typeBase == nil || depth > maxDepth || visiting[typeBase] {
+		return &Node{kind: kindJSON}
+	}
+	visiting[typeBase] = true
+	defer delete(visiting, typeBase)
+
+	switch t := (*typeBase).(type) {
+	case *types.StringType:
+		return &Node{
+			kind:      kindString,
+			Sensitive: t.Sensitive,
+			Pattern:   t.Pattern,
+			MinLength: t.MinLength,
+			MaxLength: t.MaxLength,
+		}
+	case *types.StringLiteralType:
+		return &Node{kind: kindString, OneOf: []string{t.Value}}
+	case *types.IntegerType:
+		return &Node{kind: kindInt, MinValue: t.MinValue, MaxValue: t.MaxValue}
+	case *types.BooleanType:
+		return &Node{kind: kindBool}
+	case *types.UnionType:
+		// a union of string literals is an enum, the other unions can't be represented by a single type
+		options := make([]string, 0)
+		for _, element := range t.Elements {
+			if element == nil || element.Type == nil {
+				return &Node{kind: kindJSON}
+			}
+			switch elementType := (*element.Type).(type) {
+			case *types.StringLiteralType:
+				options = append(options, elementType.Value)
+			case *types.StringType:
+				// the enum is extensible, any string is allowed
+				return &Node{kind: kindString}
+			default:
+				return &Node{kind: kindJSON}
+			}
+		}
+		return &Node{kind: kindString, OneOf: options}
+	case *types.ArrayType:
+		var itemType *types.TypeBase
+		if t.ItemType != nil {
+			itemType = t.ItemType.Type
+		}
+		return &Node{
+			kind:      kindList,
+			Element:   newNode(itemType, depth+1, visiting),
+			MinLength: t.MinLength,
+			MaxLength: t.MaxLength,
+		}
+	case *types.ObjectType:
+		if len(t.Properties) == 0 && t.AdditionalProperties != nil {
+			return &Node{kind: kindMap, Element: newNode(t.AdditionalProperties.Type, depth+1, visiting)}
+		}
+		return &Node{kind: kindObject, Attributes: newAttributes(t.Properties, depth, false, visiting)}
+	case *types.DiscriminatedObjectType:
+		// the properties of all the variants are merged, only the base properties could be required
+		attributes := newAttributes(t.BaseProperties, depth, false, visiting)
+		options := make([]string, 0)
+		for key, element := range t.Elements {
+			options = append(options, key)
+			if element == nil || element.Type == nil {
+				continue
+			}
+			if objectType, ok := (*element.Type).(*types.ObjectType); ok {
+				attributes = mergeAttributes(attributes, newAttributes(objectType.Properties, depth, true, visiting))
+			}
+		}
+		sort.Strings(options)
+		for _, attr := range attributes {
+			if attr.JsonName == t.Discriminator && attr.kind == kindString {
+				attr.OneOf = options
+				attr.Required = !attr.ComputedOnly
+			}
+		}
+		return &Node{kind: kindObject, Attributes: attributes}
+	}
+	return &Node{kind: kindJSON}
+}
+
+func newAttributes(properties map[string]types.ObjectProperty, depth int, optional bool, visiting map[*types.TypeBase]bool) []*Node {
+	attributes := make([]*Node, 0)
+	for jsonName, property := range properties {
+		tfName := TerraformName(jsonName)
+		if tfName == "" {
+			continue
+		}
+		var propertyType *types.TypeBase
+		if property.Type != nil {
+			propertyType = property.Type.Type
+		}
+		attr := newNode(propertyType, depth+1, visiting)
+		attr.JsonName = jsonName
+		attr.TfName = tfName
+		attr.ComputedOnly = property.IsReadOnly() && !property.IsRequired()
+		attr.Required = property.IsRequired() && !optional
+		attr.RequiresReplace = property.IsDeployTimeConstant()
+		if property.Description != nil {
+			attr.Description = *property.Description
+		}
+		if attr.ComputedOnly || isComputedOnlyObject(attr) {
+			markComputedOnly(attr)
+		}
+		attributes = mergeAttributes(attributes, []*Node{attr})
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].TfName < attributes[j].TfName
+	})
+	return attributes
+}
+
+// mergeAttributes appends the attributes whose Terraform names are not used yet.
+func mergeAttributes(attributes []*Node, others []*Node) []*Node {
+	existing := make(map[string]bool)
+	for _, attr := range attributes {
+		existing[attr.TfName] = true
+	}
+	for _, attr := range others {
+		if existing[attr.TfName] {
+			continue
+		}
+		existing[attr.TfName] = true
+		attributes = append(attributes, attr)
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].TfName < attributes[j].TfName
+	})
+	return attributes
+}
+
+// isComputedOnlyObject returns true if all the children of an object node are computed-only, e.g. the `properties` of a
+// resource which has no configurable property.
+func isComputedOnlyObject(node *Node) bool {
+	if node.kind != kindObject || len(node.Attributes) == 0 {
+		return false
+	}
+	for _, attr := range node.Attributes {
+		if !attr.ComputedOnly {
+			return false
+		}
+	}
+	return true
+}
+
+func markComputedOnly(node *Node) {
+	node.ComputedOnly = true
+	node.Required = false
+	node.RequiresReplace = false
+	for _, attr := range node.Attributes {
+		markComputedOnly(attr)
+	}
+	if node.Element != nil {
+		markComputedOnly(node.Element)
+	}
+}
+
+var (
+	camelBoundary   = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+	acronymBoundary = regexp.MustCompile(`([A-Z]+)([A-Z][a-z])`)
+	invalidChars    = regexp.MustCompile(`[^a-z0-9_]+`)
+	multipleUnders  = regexp.MustCompile(`_+`)
+)
+
+// TerraformName converts a property name in camel case to a valid Terraform attribute name in snake case, e.g. `privateIPAddress`
+// is converted to `private_ip_address`. It returns an empty string if the name can't be converted.
+func TerraformName(name string) string {
+	res := acronymBoundary.ReplaceAllString(name, "${1}_${2}")
+	res = camelBoundary.ReplaceAllString(res, "${1}_${2}")
+	res = strings.ToLower(res)
+	res = invalidChars.ReplaceAllString(res, "_")
+	res = multipleUnders.ReplaceAllString(res, "_")
+	res = strings.Trim(res, "_")
+	if res == "" {
+		return ""
+	}
+	if res[0] >= '0' && res[0] <= '9' {
+		res = "_" + res
+	}
+	return res
+}
+
+// ResourceName converts a resource type and API version to the suffix of the typed resource name, e.g. `Microsoft.Resources/resourceGroups`
+// and `2023-07-01` is converted to `microsoft_resources_resource_groups_2023_07_01`.
+func ResourceName(resourceType, apiVersion string) string {
+	parts := make([]string, 0)
+	for _, part := range strings.Split(resourceType, "/") {
+		parts = append(parts, TerraformName(strings.ReplaceAll(part, ".", "_")))
+	}
+	parts = append(parts, invalidChars.ReplaceAllString(strings.ToLower(apiVersion), "_"))
+	return strings.Join(parts, "_")
+}
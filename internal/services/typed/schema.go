@@ -0,0 +1,220 @@
+package typed
+
+import (
+	"regexp"
+
+	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// SchemaAttributes returns the framework attributes of the children of an object node.
+func SchemaAttributes(node *Node) map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(node.Attributes))
+	for _, child := range node.Attributes {
+		attributes[child.TfName] = schemaAttribute(child)
+	}
+	return attributes
+}
+
+func schemaAttribute(node *Node) schema.Attribute {
+	required := node.Required
+	optional := !node.Required && !node.ComputedOnly
+	computed := node.ComputedOnly
+
+	switch node.kind {
+	case kindString, kindJSON:
+		planModifiers := make([]planmodifier.String, 0)
+		if node.RequiresReplace {
+			planModifiers = append(planModifiers, stringplanmodifier.RequiresReplace())
+		}
+		if computed {
+			planModifiers = append(planModifiers, stringplanmodifier.UseStateForUnknown())
+		}
+		return schema.StringAttribute{
+			Required:      required,
+			Optional:      optional,
+			Computed:      computed,
+			Sensitive:     node.Sensitive,
+			Description:   node.description(),
+			PlanModifiers: planModifiers,
+			Validators:    node.stringValidators(),
+		}
+	case kindInt:
+		planModifiers := make([]planmodifier.Int64, 0)
+		if node.RequiresReplace {
+			planModifiers = append(planModifiers, int64planmodifier.RequiresReplace())
+		}
+		if computed {
+			planModifiers = append(planModifiers, int64planmodifier.UseStateForUnknown())
+		}
+		validators := make([]validator.Int64, 0)
+		if node.MinValue != nil {
+			validators = append(validators, int64validator.AtLeast(int64(*node.MinValue)))
+		}
+		if node.MaxValue != nil {
+			validators = append(validators, int64validator.AtMost(int64(*node.MaxValue)))
+		}
+		return schema.Int64Attribute{
+			Required:      required,
+			Optional:      optional,
+			Computed:      computed,
+			Description:   node.description(),
+			PlanModifiers: planModifiers,
+			Validators:    validators,
+		}
+	case kindBool:
+		planModifiers := make([]planmodifier.Bool, 0)
+		if node.RequiresReplace {
+			planModifiers = append(planModifiers, boolplanmodifier.RequiresReplace())
+		}
+		if computed {
+			planModifiers = append(planModifiers, boolplanmodifier.UseStateForUnknown())
+		}
+		return schema.BoolAttribute{
+			Required:      required,
+			Optional:      optional,
+			Computed:      computed,
+			Description:   node.description(),
+			PlanModifiers: planModifiers,
+		}
+	case kindObject:
+		planModifiers := make([]planmodifier.Object, 0)
+		if node.RequiresReplace {
+			planModifiers = append(planModifiers, objectplanmodifier.RequiresReplace())
+		}
+		return schema.SingleNestedAttribute{
+			Required:      required,
+			Optional:      optional,
+			Computed:      computed,
+			Description:   node.description(),
+			Attributes:    SchemaAttributes(node),
+			PlanModifiers: planModifiers,
+		}
+	case kindList:
+		planModifiers := make([]planmodifier.List, 0)
+		if node.RequiresReplace {
+			planModifiers = append(planModifiers, listplanmodifier.RequiresReplace())
+		}
+		validators := make([]validator.List, 0)
+		if node.MinLength != nil {
+			validators = append(validators, listvalidator.SizeAtLeast(*node.MinLength))
+		}
+		if node.MaxLength != nil {
+			validators = append(validators, listvalidator.SizeAtMost(*node.MaxLength))
+		}
+		if node.Element.kind == kindObject {
+			return schema.ListNestedAttribute{
+				Required:      required,
+				Optional:      optional,
+				Computed:      computed,
+				Description:   node.description(),
+				NestedObject:  schema.NestedAttributeObject{Attributes: SchemaAttributes(node.Element)},
+				PlanModifiers: planModifiers,
+				Validators:    validators,
+			}
+		}
+		return schema.ListAttribute{
+			Required:      required,
+			Optional:      optional,
+			Computed:      computed,
+			Description:   node.description(),
+			ElementType:   node.Element.AttrType(),
+			PlanModifiers: planModifiers,
+			Validators:    validators,
+		}
+	case kindMap:
+		planModifiers := make([]planmodifier.Map, 0)
+		if node.RequiresReplace {
+			planModifiers = append(planModifiers, mapplanmodifier.RequiresReplace())
+		}
+		if node.Element.kind == kindObject {
+			return schema.MapNestedAttribute{
+				Required:      required,
+				Optional:      optional,
+				Computed:      computed,
+				Description:   node.description(),
+				NestedObject:  schema.NestedAttributeObject{Attributes: SchemaAttributes(node.Element)},
+				PlanModifiers: planModifiers,
+			}
+		}
+		return schema.MapAttribute{
+			Required:      required,
+			Optional:      optional,
+			Computed:      computed,
+			Description:   node.description(),
+			ElementType:   node.Element.AttrType(),
+			PlanModifiers: planModifiers,
+		}
+	}
+	return nil
+}
+
+// AttrType returns the framework type of the node.
+func (n *Node) AttrType() attr.Type {
+	switch n.kind {
+	case kindInt:
+		return types.Int64Type
+	case kindBool:
+		return types.BoolType
+	case kindObject:
+		attrTypes := make(map[string]attr.Type, len(n.Attributes))
+		for _, child := range n.Attributes {
+			attrTypes[child.TfName] = child.AttrType()
+		}
+		return types.ObjectType{AttrTypes: attrTypes}
+	case kindList:
+		return types.ListType{ElemType: n.Element.AttrType()}
+	case kindMap:
+		return types.MapType{ElemType: n.Element.AttrType()}
+	}
+	return types.StringType
+}
+
+func (n *Node) description() string {
+	if n.kind == kindJSON {
+		if n.Description == "" {
+			return "A JSON string."
+		}
+		return n.Description + " It's a JSON string."
+	}
+	return n.Description
+}
+
+func (n *Node) stringValidators() []validator.String {
+	validators := make([]validator.String, 0)
+	if n.ComputedOnly {
+		return validators
+	}
+	if n.kind == kindJSON {
+		return append(validators, myvalidator.StringIsJSON())
+	}
+	if len(n.OneOf) != 0 {
+		validators = append(validators, stringvalidator.OneOf(n.OneOf...))
+	}
+	if n.MinLength != nil {
+		validators = append(validators, stringvalidator.LengthAtLeast(*n.MinLength))
+	}
+	if n.MaxLength != nil {
+		validators = append(validators, stringvalidator.LengthAtMost(*n.MaxLength))
+	}
+	// the patterns which are not supported by the Go regular expression are ignored
+	if n.Pattern != "" {
+		if re, err := regexp.Compile(n.Pattern); err == nil {
+			validators = append(validators, stringvalidator.RegexMatches(re, "must match the pattern "+n.Pattern))
+		}
+	}
+	return validators
+}
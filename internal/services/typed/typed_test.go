@@ -0,0 +1,282 @@
+package typed_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+	"github.com/Azure/terraform-provider-azapi/internal/services/typed"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func property(t types.TypeBase, flags ...types.ObjectPropertyFlag) types.ObjectProperty {
+	return types.ObjectProperty{
+		Type:  &types.TypeReference{Type: t.AsTypeBase()},
+		Flags: flags,
+	}
+}
+
+func testResourceType() *types.ResourceType {
+	ruleType := &types.ObjectType{
+		Properties: map[string]types.ObjectProperty{
+			"name":     property(&types.StringType{}, types.Required),
+			"priority": property(&types.IntegerType{MinValue: intPtr(100), MaxValue: intPtr(4096)}),
+			"etag":     property(&types.StringType{}, types.ReadOnly),
+		},
+	}
+	propertiesType := &types.ObjectType{
+		Properties: map[string]types.ObjectProperty{
+			"provisioningState":      property(&types.StringType{}, types.ReadOnly),
+			"enableHttpsTrafficOnly": property(&types.BooleanType{}),
+			"accessTier": property(&types.UnionType{Elements: []*types.TypeReference{
+				{Type: (&types.StringLiteralType{Value: "Hot"}).AsTypeBase()},
+				{Type: (&types.StringLiteralType{Value: "Cool"}).AsTypeBase()},
+			}}),
+			"rules":    property(&types.ArrayType{ItemType: &types.TypeReference{Type: ruleType.AsTypeBase()}, MaxLength: intPtr(10)}),
+			"metadata": property(&types.AnyType{}),
+		},
+	}
+	bodyType := &types.ObjectType{
+		Properties: map[string]types.ObjectProperty{
+			"id":         property(&types.StringType{}, types.ReadOnly),
+			"type":       property(&types.StringType{}, types.ReadOnly),
+			"apiVersion": property(&types.StringType{}, types.ReadOnly),
+			"name":       property(&types.StringType{Pattern: "^[a-z0-9]{3,24}$"}, types.Required, types.DeployTimeConstant),
+			"location":   property(&types.StringType{}, types.Required, types.DeployTimeConstant),
+			"tags": property(&types.ObjectType{
+				AdditionalProperties: &types.TypeReference{Type: (&types.StringType{}).AsTypeBase()},
+			}),
+			"properties": property(propertiesType),
+			"systemData": property(&types.ObjectType{
+				Properties: map[string]types.ObjectProperty{
+					"createdBy": property(&types.StringType{}, types.ReadOnly),
+				},
+			}),
+		},
+	}
+	return &types.ResourceType{
+		Body: &types.TypeReference{Type: bodyType.AsTypeBase()},
+	}
+}
+
+func Test_NewBodyNode(t *testing.T) {
+	node, err := typed.NewBodyNode(testResourceType())
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	attributes := typed.SchemaAttributes(node)
+	if diags := (schema.Schema{Attributes: attributes}).ValidateImplementation(context.Background()); diags.HasError() {
+		t.Fatalf("invalid schema: %+v", diags)
+	}
+	for _, name := range []string{"id", "type", "api_version"} {
+		if _, ok := attributes[name]; ok {
+			t.Fatalf("expected attribute %q to be excluded", name)
+		}
+	}
+
+	name, ok := attributes["name"].(schema.StringAttribute)
+	if !ok || !name.Required || len(name.PlanModifiers) != 1 || len(name.Validators) != 1 {
+		t.Fatalf("expected name to be a required string attribute which requires replacement and has a validator, got %+v", attributes["name"])
+	}
+	if _, ok := attributes["tags"].(schema.MapAttribute); !ok {
+		t.Fatalf("expected tags to be a map attribute, got %+v", attributes["tags"])
+	}
+
+	if v, ok := attributes["system_data"].(schema.SingleNestedAttribute); !ok || !v.Computed || v.Optional {
+		t.Fatalf("expected system_data to be a computed attribute, got %+v", attributes["system_data"])
+	}
+
+	properties, ok := attributes["properties"].(schema.SingleNestedAttribute)
+	if !ok || !properties.Optional {
+		t.Fatalf("expected properties to be an optional nested attribute, got %+v", attributes["properties"])
+	}
+	if v, ok := properties.Attributes["provisioning_state"].(schema.StringAttribute); !ok || !v.Computed || v.Optional {
+		t.Fatalf("expected provisioning_state to be a computed attribute, got %+v", properties.Attributes["provisioning_state"])
+	}
+	if v, ok := properties.Attributes["enable_https_traffic_only"].(schema.BoolAttribute); !ok || !v.Optional {
+		t.Fatalf("expected enable_https_traffic_only to be an optional bool attribute, got %+v", properties.Attributes["enable_https_traffic_only"])
+	}
+	if v, ok := properties.Attributes["access_tier"].(schema.StringAttribute); !ok || len(v.Validators) != 1 {
+		t.Fatalf("expected access_tier to be a string attribute with a validator, got %+v", properties.Attributes["access_tier"])
+	}
+	if v, ok := properties.Attributes["metadata"].(schema.StringAttribute); !ok || len(v.Validators) != 1 {
+		t.Fatalf("expected metadata to be a JSON string attribute, got %+v", properties.Attributes["metadata"])
+	}
+	rules, ok := properties.Attributes["rules"].(schema.ListNestedAttribute)
+	if !ok || len(rules.Validators) != 1 {
+		t.Fatalf("expected rules to be a list nested attribute with a validator, got %+v", properties.Attributes["rules"])
+	}
+	if v, ok := rules.NestedObject.Attributes["priority"].(schema.Int64Attribute); !ok || len(v.Validators) != 2 {
+		t.Fatalf("expected priority to be an int attribute with validators, got %+v", rules.NestedObject.Attributes["priority"])
+	}
+	if v, ok := rules.NestedObject.Attributes["name"].(schema.StringAttribute); !ok || !v.Required {
+		t.Fatalf("expected the rule's name to be required, got %+v", rules.NestedObject.Attributes["name"])
+	}
+}
+
+func Test_NewBodyNodeRecursiveType(t *testing.T) {
+	recursiveType := &types.ObjectType{Properties: map[string]types.ObjectProperty{}}
+	typeBase := recursiveType.AsTypeBase()
+	recursiveType.Properties["name"] = property(&types.StringType{})
+	recursiveType.Properties["children"] = types.ObjectProperty{
+		Type: &types.TypeReference{Type: typeBase},
+	}
+
+	node, err := typed.NewBodyNode(&types.ResourceType{Body: &types.TypeReference{Type: typeBase}})
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if _, ok := typed.SchemaAttributes(node)["children"].(schema.StringAttribute); !ok {
+		t.Fatalf("expected the recursive property to be a JSON string attribute")
+	}
+}
+
+func Test_TerraformName(t *testing.T) {
+	testcases := map[string]string{
+		"name":                   "name",
+		"privateIPAddress":       "private_ip_address",
+		"enableHttpsTrafficOnly": "enable_https_traffic_only",
+		"$schema":                "schema",
+		"1stValue":               "_1st_value",
+		"@odata.type":            "odata_type",
+		"--":                     "",
+	}
+	for input, expected := range testcases {
+		if actual := typed.TerraformName(input); actual != expected {
+			t.Fatalf("expected %q for %q but got %q", expected, input, actual)
+		}
+	}
+
+	if actual := typed.ResourceName("Microsoft.Resources/resourceGroups", "2023-07-01"); actual != "microsoft_resources_resource_groups_2023_07_01" {
+		t.Fatalf("unexpected resource name %q", actual)
+	}
+}
+
+func Test_ExpandFlattenAttributes(t *testing.T) {
+	node, err := typed.NewBodyNode(testResourceType())
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	objectType, ok := node.TerraformType().(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected an object type")
+	}
+
+	var config map[string]interface{}
+	_ = json.Unmarshal([]byte(`{
+  "name": "example",
+  "location": "westeurope",
+  "properties": {
+    "provisioningState": "Succeeded",
+    "accessTier": "Hot",
+    "metadata": {"owner": "team"},
+    "rules": [{"name": "rule1", "priority": 100, "etag": "1"}]
+  }
+}`), &config)
+
+	// importing takes all the values from the response
+	imported, err := typed.FlattenAttributes(node, nil, config, typed.FlattenImport)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if !imported["tags"].IsNull() {
+		t.Fatalf("expected tags to be null")
+	}
+
+	// the computed-only values are not sent to the API
+	body, err := typed.ExpandAttributes(node, imported)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	var expected map[string]interface{}
+	_ = json.Unmarshal([]byte(`{
+  "name": "example",
+  "location": "westeurope",
+  "properties": {
+    "accessTier": "Hot",
+    "metadata": {"owner": "team"},
+    "rules": [{"name": "rule1", "priority": 100}]
+  }
+}`), &expected)
+	actual, _ := json.Marshal(body)
+	var actualObj map[string]interface{}
+	_ = json.Unmarshal(actual, &actualObj)
+	if !reflect.DeepEqual(expected, actualObj) {
+		t.Fatalf("expected %v but got %v", expected, actualObj)
+	}
+
+	// reading keeps the values which are not tracked, and the values with different casing
+	var response map[string]interface{}
+	_ = json.Unmarshal([]byte(`{
+  "name": "EXAMPLE",
+  "location": "West Europe",
+  "tags": {"env": "prod"},
+  "properties": {
+    "provisioningState": "Updating",
+    "accessTier": "Cool",
+    "metadata": {"owner": "team", "createdBy": "system"},
+    "rules": [{"name": "rule1", "priority": 200, "etag": "2"}]
+  }
+}`), &response)
+	read, err := typed.FlattenAttributes(node, imported, response, typed.FlattenRead)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	state := tftypes.NewValue(objectType, read)
+	assertValue(t, state, tftypes.NewAttributePath().WithAttributeName("name"), "example")
+	assertValue(t, state, tftypes.NewAttributePath().WithAttributeName("location"), "westeurope")
+	assertValue(t, state, tftypes.NewAttributePath().WithAttributeName("tags"), nil)
+	properties := tftypes.NewAttributePath().WithAttributeName("properties")
+	assertValue(t, state, properties.WithAttributeName("provisioning_state"), "Updating")
+	assertValue(t, state, properties.WithAttributeName("access_tier"), "Cool")
+	assertValue(t, state, properties.WithAttributeName("metadata"), `{"owner":"team"}`)
+	assertValue(t, state, properties.WithAttributeName("rules").WithElementKeyInt(0).WithAttributeName("priority"), big.NewFloat(200))
+
+	// applying keeps the planned values and fills the computed-only values
+	applied, err := typed.FlattenAttributes(node, imported, response, typed.FlattenApply)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	state = tftypes.NewValue(objectType, applied)
+	assertValue(t, state, properties.WithAttributeName("access_tier"), "Hot")
+	assertValue(t, state, properties.WithAttributeName("provisioning_state"), "Updating")
+	assertValue(t, state, properties.WithAttributeName("rules").WithElementKeyInt(0).WithAttributeName("etag"), "2")
+}
+
+func assertValue(t *testing.T, state tftypes.Value, path *tftypes.AttributePath, expected interface{}) {
+	v, _, err := tftypes.WalkAttributePath(state, path)
+	if err != nil {
+		t.Fatalf("walking %s: %+v", path, err)
+	}
+	value, ok := v.(tftypes.Value)
+	if !ok {
+		t.Fatalf("expected a value at %s", path)
+	}
+	switch expectedValue := expected.(type) {
+	case nil:
+		if !value.IsNull() {
+			t.Fatalf("expected null at %s but got %s", path, value)
+		}
+	case string:
+		var actual string
+		_ = value.As(&actual)
+		if actual != expectedValue {
+			t.Fatalf("expected %q at %s but got %q", expectedValue, path, actual)
+		}
+	case *big.Float:
+		actual := big.NewFloat(0)
+		_ = value.As(&actual)
+		if actual.Cmp(expectedValue) != 0 {
+			t.Fatalf("expected %s at %s but got %s", expectedValue, path, actual)
+		}
+	}
+}
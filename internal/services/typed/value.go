@@ -0,0 +1,302 @@
+package typed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/location"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// FlattenMode controls how the values in the response are merged with the prior values.
+type FlattenMode int
+
+const (
+	// FlattenApply keeps the planned values and only fills the computed-only attributes from the response.
+	FlattenApply FlattenMode = iota
+	// FlattenRead updates the values which are tracked in the prior state with the values in the response,
+	// the values missing in the response are kept.
+	FlattenRead
+	// FlattenImport takes all the values from the response.
+	FlattenImport
+)
+
+// TerraformType returns the terraform-plugin-go type of the node.
+func (n *Node) TerraformType() tftypes.Type {
+	return n.AttrType().TerraformType(context.Background())
+}
+
+// ExpandAttributes builds the request body from the attribute values of an object node, the computed-only attributes
+// and the null values are not included.
+func ExpandAttributes(node *Node, values map[string]tftypes.Value) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	for _, child := range node.Attributes {
+		if child.ComputedOnly {
+			continue
+		}
+		value, ok := values[child.TfName]
+		if !ok {
+			continue
+		}
+		expanded, err := expand(child, value)
+		if err != nil {
+			return nil, fmt.Errorf("expanding %s: %+v", child.TfName, err)
+		}
+		if expanded != nil {
+			out[child.JsonName] = expanded
+		}
+	}
+	return out, nil
+}
+
+func expand(node *Node, value tftypes.Value) (interface{}, error) {
+	if value.IsNull() || !value.IsKnown() {
+		return nil, nil
+	}
+	switch node.kind {
+	case kindString:
+		var v string
+		err := value.As(&v)
+		return v, err
+	case kindJSON:
+		var v string
+		if err := value.As(&v); err != nil {
+			return nil, err
+		}
+		var out interface{}
+		err := json.Unmarshal([]byte(v), &out)
+		return out, err
+	case kindInt:
+		v := big.NewFloat(0)
+		if err := value.As(&v); err != nil {
+			return nil, err
+		}
+		out, _ := v.Int64()
+		return out, nil
+	case kindBool:
+		var v bool
+		err := value.As(&v)
+		return v, err
+	case kindObject:
+		values := make(map[string]tftypes.Value)
+		if err := value.As(&values); err != nil {
+			return nil, err
+		}
+		return ExpandAttributes(node, values)
+	case kindList:
+		values := make([]tftypes.Value, 0)
+		if err := value.As(&values); err != nil {
+			return nil, err
+		}
+		out := make([]interface{}, 0)
+		for _, element := range values {
+			expanded, err := expand(node.Element, element)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, expanded)
+		}
+		return out, nil
+	case kindMap:
+		values := make(map[string]tftypes.Value)
+		if err := value.As(&values); err != nil {
+			return nil, err
+		}
+		out := make(map[string]interface{})
+		for key, element := range values {
+			expanded, err := expand(node.Element, element)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = expanded
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("unsupported kind %d", node.kind)
+}
+
+// FlattenAttributes builds the attribute values of an object node from the response body and the prior values.
+func FlattenAttributes(node *Node, prior map[string]tftypes.Value, body interface{}, mode FlattenMode) (map[string]tftypes.Value, error) {
+	bodyMap, _ := body.(map[string]interface{})
+	out := make(map[string]tftypes.Value)
+	for _, child := range node.Attributes {
+		priorValue, ok := prior[child.TfName]
+		if !ok {
+			priorValue = tftypes.NewValue(child.TerraformType(), nil)
+		}
+		value, err := flatten(child, priorValue, bodyMap[child.JsonName], mode)
+		if err != nil {
+			return nil, fmt.Errorf("flattening %s: %+v", child.TfName, err)
+		}
+		out[child.TfName] = value
+	}
+	return out, nil
+}
+
+func flatten(node *Node, prior tftypes.Value, body interface{}, mode FlattenMode) (tftypes.Value, error) {
+	typ := node.TerraformType()
+	null := tftypes.NewValue(typ, nil)
+
+	if node.ComputedOnly || !prior.IsKnown() {
+		mode = FlattenImport
+	}
+	switch mode {
+	case FlattenApply:
+		if prior.IsNull() {
+			return prior, nil
+		}
+		switch node.kind {
+		case kindObject, kindList, kindMap:
+		default:
+			return prior, nil
+		}
+	case FlattenRead:
+		if prior.IsNull() || body == nil {
+			return prior, nil
+		}
+	case FlattenImport:
+		if body == nil {
+			return null, nil
+		}
+	}
+
+	switch node.kind {
+	case kindString:
+		v, ok := body.(string)
+		if !ok {
+			v = fmt.Sprintf("%v", body)
+		}
+		if mode == FlattenRead {
+			var priorValue string
+			if err := prior.As(&priorValue); err != nil {
+				return null, err
+			}
+			if strings.EqualFold(priorValue, v) || (node.JsonName == "location" && location.Normalize(priorValue) == location.Normalize(v)) {
+				return prior, nil
+			}
+		}
+		return tftypes.NewValue(typ, v), nil
+	case kindJSON:
+		if mode == FlattenRead {
+			var priorValue string
+			if err := prior.As(&priorValue); err != nil {
+				return null, err
+			}
+			var priorObj interface{}
+			if err := json.Unmarshal([]byte(priorValue), &priorObj); err == nil {
+				body = utils.UpdateObject(priorObj, body, utils.UpdateJsonOption{IgnoreCasing: true, IgnoreMissingProperty: true})
+				if reflect.DeepEqual(utils.NormalizeObject(priorObj), utils.NormalizeObject(body)) {
+					return prior, nil
+				}
+			}
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			return null, err
+		}
+		return tftypes.NewValue(typ, string(data)), nil
+	case kindInt:
+		if v, ok := body.(float64); ok {
+			return tftypes.NewValue(typ, big.NewFloat(v)), nil
+		}
+		return null, nil
+	case kindBool:
+		if v, ok := body.(bool); ok {
+			return tftypes.NewValue(typ, v), nil
+		}
+		return null, nil
+	case kindObject:
+		priorValues := make(map[string]tftypes.Value)
+		if !prior.IsNull() && prior.IsKnown() {
+			if err := prior.As(&priorValues); err != nil {
+				return null, err
+			}
+		}
+		if _, ok := body.(map[string]interface{}); !ok && mode != FlattenApply {
+			return null, nil
+		}
+		values, err := FlattenAttributes(node, priorValues, body, mode)
+		if err != nil {
+			return null, err
+		}
+		return tftypes.NewValue(typ, values), nil
+	case kindList:
+		priorValues := make([]tftypes.Value, 0)
+		if !prior.IsNull() && prior.IsKnown() {
+			if err := prior.As(&priorValues); err != nil {
+				return null, err
+			}
+		}
+		bodyList, ok := body.([]interface{})
+		if !ok && mode != FlattenApply {
+			return null, nil
+		}
+		length := len(bodyList)
+		if mode == FlattenApply {
+			length = len(priorValues)
+		}
+		values := make([]tftypes.Value, 0)
+		for i := 0; i < length; i++ {
+			var element interface{}
+			if i < len(bodyList) {
+				element = bodyList[i]
+			}
+			value, err := flattenElement(node.Element, priorValues, i, element, mode)
+			if err != nil {
+				return null, err
+			}
+			values = append(values, value)
+		}
+		return tftypes.NewValue(typ, values), nil
+	case kindMap:
+		priorValues := make(map[string]tftypes.Value)
+		if !prior.IsNull() && prior.IsKnown() {
+			if err := prior.As(&priorValues); err != nil {
+				return null, err
+			}
+		}
+		bodyMap, ok := body.(map[string]interface{})
+		if !ok && mode != FlattenApply {
+			return null, nil
+		}
+		keys := make([]string, 0)
+		if mode == FlattenApply {
+			for key := range priorValues {
+				keys = append(keys, key)
+			}
+		} else {
+			for key := range bodyMap {
+				keys = append(keys, key)
+			}
+		}
+		values := make(map[string]tftypes.Value)
+		for _, key := range keys {
+			elementMode := mode
+			priorValue, ok := priorValues[key]
+			if !ok {
+				priorValue = tftypes.NewValue(node.Element.TerraformType(), nil)
+				elementMode = FlattenImport
+			}
+			value, err := flatten(node.Element, priorValue, bodyMap[key], elementMode)
+			if err != nil {
+				return null, err
+			}
+			values[key] = value
+		}
+		return tftypes.NewValue(typ, values), nil
+	}
+	return null, fmt.Errorf("unsupported kind %d", node.kind)
+}
+
+// flattenElement flattens the element of a list, the new elements which don't exist in the prior values are taken from the response.
+func flattenElement(node *Node, priorValues []tftypes.Value, index int, body interface{}, mode FlattenMode) (tftypes.Value, error) {
+	if index >= len(priorValues) {
+		return flatten(node, tftypes.NewValue(node.TerraformType(), nil), body, FlattenImport)
+	}
+	return flatten(node, priorValues[index], body, mode)
+}
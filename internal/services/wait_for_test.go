@@ -0,0 +1,122 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/services"
+	"github.com/Azure/terraform-provider-azapi/internal/waitfor"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// waitForServer returns a server on which the resource exists after the PUT request, but its state never becomes `Ok`.
+func waitForServer(t *testing.T, existing bool) (*httptest.Server, *atomic.Int32) {
+	var puts atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.EqualFold(r.URL.Path, testResourceId) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"NotFound","message":"not found"}}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodPut:
+			puts.Add(1)
+		case !existing && puts.Load() == 0:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"ResourceNotFound","message":"not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"` + testResourceId + `","properties":{"state":"Creating"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &puts
+}
+
+// waitForPlan returns a plan of the resource with the attributes set and a wait_for block which never holds in time.
+func waitForPlan(t *testing.T, r resource.Resource, attributes map[string]attr.Value) tfsdk.Plan {
+	attributes["wait_for"] = types.ObjectValueMust(waitfor.Model{}.AttrType(), map[string]attr.Value{
+		"path":             types.StringValue("properties.state"),
+		"values":           types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Ok")}),
+		"interval_seconds": types.Int64Value(1),
+		"timeout_seconds":  types.Int64Value(1),
+		"before_delete":    types.BoolNull(),
+	})
+	return testPlan(t, r, attributes)
+}
+
+// assertStoredWithWaitFailure checks the resource is stored in the state, and the wait_for failure is reported as an error,
+// or as a warning if the resource is created, because Terraform would taint it if an error is returned.
+func assertStoredWithWaitFailure(t *testing.T, state tfsdk.State, diags diag.Diagnostics, puts *atomic.Int32, isNewResource bool) {
+	if puts.Load() != 1 {
+		t.Fatalf("expected the PUT request to be sent once, got %d", puts.Load())
+	}
+	if isNewResource {
+		if diags.HasError() || len(diags.Warnings()) != 1 || !strings.Contains(diags.Warnings()[0].Summary(), "Failed to wait for resource") {
+			t.Fatalf("expected only the wait_for warning, got %+v", diags)
+		}
+	} else if !diags.HasError() || !strings.Contains(diags.Errors()[0].Summary(), "Failed to wait for resource") {
+		t.Fatalf("expected the wait_for error, got %+v", diags)
+	}
+	var id types.String
+	if d := state.GetAttribute(context.Background(), path.Root("id"), &id); d.HasError() || id.ValueString() == "" {
+		t.Fatalf("expected the resource to be stored in the state, got id %v: %+v", id, d)
+	}
+}
+
+func TestAzapiResource_waitForTimeoutAfterCreate(t *testing.T) {
+	server, puts := waitForServer(t, false)
+	r := &services.AzapiResource{ProviderData: testProviderData(t, server)}
+	plan := waitForPlan(t, r, map[string]attr.Value{
+		"type":      types.StringValue("Microsoft.Automation/automationAccounts@2023-11-01"),
+		"name":      types.StringValue("test"),
+		"parent_id": types.StringValue("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg"),
+		"body":      types.StringValue("{}"),
+	})
+	state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+
+	var diags diag.Diagnostics
+	r.CreateUpdate(context.Background(), plan, &state, nil, &diags)
+	assertStoredWithWaitFailure(t, state, diags, puts, true)
+}
+
+func TestAzapiUpdateResource_waitForTimeoutAfterCreate(t *testing.T) {
+	server, puts := waitForServer(t, true)
+	r := &services.AzapiUpdateResource{ProviderData: testProviderData(t, server)}
+	plan := waitForPlan(t, r, map[string]attr.Value{
+		"type":        types.StringValue("Microsoft.Automation/automationAccounts@2023-11-01"),
+		"resource_id": types.StringValue(testResourceId),
+		"body":        types.StringValue("{}"),
+	})
+	state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+
+	var diags diag.Diagnostics
+	r.CreateUpdate(context.Background(), plan, &state, &diags)
+	assertStoredWithWaitFailure(t, state, diags, puts, true)
+}
+
+func TestAzapiUpdateResource_waitForTimeoutAfterUpdate(t *testing.T) {
+	server, puts := waitForServer(t, true)
+	r := &services.AzapiUpdateResource{ProviderData: testProviderData(t, server)}
+	plan := waitForPlan(t, r, map[string]attr.Value{
+		"id":          types.StringValue(testResourceId),
+		"type":        types.StringValue("Microsoft.Automation/automationAccounts@2023-11-01"),
+		"resource_id": types.StringValue(testResourceId),
+		"body":        types.StringValue("{}"),
+	})
+	state := tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}
+
+	var diags diag.Diagnostics
+	r.CreateUpdate(context.Background(), plan, &state, &diags)
+	assertStoredWithWaitFailure(t, state, diags, puts, false)
+}
@@ -0,0 +1,115 @@
+package waitfor
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type Model struct {
+	Path            types.String `tfsdk:"path"`
+	Values          types.List   `tfsdk:"values"`
+	IntervalSeconds types.Int64  `tfsdk:"interval_seconds"`
+	TimeoutSeconds  types.Int64  `tfsdk:"timeout_seconds"`
+	BeforeDelete    types.Bool   `tfsdk:"before_delete"`
+}
+
+func (m Model) ModelType() attr.Type {
+	return types.ObjectType{AttrTypes: m.AttrType()}
+}
+
+func (m Model) AttrType() map[string]attr.Type {
+	return map[string]attr.Type{
+		"path":             types.StringType,
+		"values":           types.ListType{ElemType: types.StringType},
+		"interval_seconds": types.Int64Type,
+		"timeout_seconds":  types.Int64Type,
+		"before_delete":    types.BoolType,
+	}
+}
+
+func Block() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					myvalidator.StringIsNotEmpty(),
+					myvalidator.StringIsExtractPath(),
+				},
+			},
+
+			"values": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+
+			"interval_seconds": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"timeout_seconds": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"before_delete": schema.BoolAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+// ExpandWaitFor converts the `wait_for` block into the wait options used by the clients, it returns nil if the block is not specified.
+func ExpandWaitFor(input types.Object) *clients.WaitForOptions {
+	if input.IsNull() || input.IsUnknown() {
+		return nil
+	}
+
+	var model Model
+	if diags := input.As(context.Background(), &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		tflog.Warn(context.Background(), "failed to convert the wait_for block")
+		return nil
+	}
+	if model.Path.IsNull() || model.Path.IsUnknown() {
+		return nil
+	}
+
+	options := &clients.WaitForOptions{
+		Path:         model.Path.ValueString(),
+		Values:       make([]string, 0),
+		BeforeDelete: model.BeforeDelete.ValueBool(),
+	}
+	for _, element := range model.Values.Elements() {
+		v, ok := element.(types.String)
+		if !ok || v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		options.Values = append(options.Values, v.ValueString())
+	}
+	if v := model.IntervalSeconds.ValueInt64(); v > 0 {
+		options.Interval = time.Duration(v) * time.Second
+	}
+	if v := model.TimeoutSeconds.ValueInt64(); v > 0 {
+		options.Timeout = time.Duration(v) * time.Second
+	}
+	return options
+}
@@ -7,11 +7,16 @@ main() {
     bicep_dir=$1
     echo "$bicep_dir/generated"
     echo "$ROOTDIR/internal/azure/generated"
+    echo "keeping the data plane type files..."
+    dataplane_dir=$(mktemp -d)
+    cp -r "$ROOTDIR/internal/azure/generated/dataplane" "$dataplane_dir/"
     echo "removing all exist type files..."
     rm -r "$ROOTDIR/internal/azure/generated"
     echo "done"
     echo "copying new type files"
     cp -r "$bicep_dir/generated" "$ROOTDIR/internal/azure/generated"
+    cp -r "$dataplane_dir/dataplane" "$ROOTDIR/internal/azure/generated/"
+    rm -r "$dataplane_dir"
     echo "done"
     cd $ROOTDIR/internal/azure/generated
     find . -name "*.md" -type f -delete
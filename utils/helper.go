@@ -25,6 +25,16 @@ func GetId(resource interface{}) *string {
 	return nil
 }
 
+// GetETag returns the `etag` property of the resource, it returns an empty string if the resource doesn't have one.
+func GetETag(resource interface{}) string {
+	if resourceMap, ok := resource.(map[string]interface{}); ok {
+		if etag, ok := resourceMap["etag"].(string); ok {
+			return etag
+		}
+	}
+	return ""
+}
+
 func GetResourceType(id string) string {
 	if id == "/" {
 		return arm.TenantResourceType.String()
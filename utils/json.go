@@ -3,8 +3,14 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
+	"log"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/jmespath/go-jmespath"
 )
 
 func NormalizeJson(jsonString interface{}) string {
@@ -103,36 +109,281 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 	return new
 }
 
-// ExtractObject is used to extract object from old for a json path
+// ExtractObject is used to extract object from old for a json path, the result keeps the same structure as old.
+// The path consists of segments separated by dots, each segment could be: a key, e.g. `properties` or `primary-key`,
+// a quoted key which contains dots, e.g. `"key.with.dots"`, a wildcard which matches all keys or elements, e.g. `*` or `[*]`,
+// an index of an array, e.g. `[0]` or `[-1]`, or a JMESPath filter of an array, e.g. `[?name=='default']`.
+// The elements selected by wildcards and indexes keep their positions in the array, the elements which aren't selected are nil.
+// It returns nil if the path is invalid, the paths from the configuration should be checked by ValidateExtractPath.
 func ExtractObject(old interface{}, path string) interface{} {
 	if len(path) == 0 {
 		return old
 	}
-	if oldMap, ok := old.(map[string]interface{}); ok {
-		index := strings.Index(path, ".")
-		if index != -1 {
-			key := path[0:index]
-			result := make(map[string]interface{}, 1)
-			value := ExtractObject(oldMap[key], path[index+1:])
-			if value == nil {
-				return nil
-			} else {
-				result[key] = value
+	segments, err := parseExtractPath(path)
+	if err != nil {
+		log.Printf("[WARN] failed to extract object: %+v", err)
+		return nil
+	}
+	return extractObject(old, segments)
+}
+
+// ValidateExtractPath checks whether the path is supported by ExtractObject.
+func ValidateExtractPath(path string) error {
+	_, err := parseExtractPath(path)
+	return err
+}
+
+type extractSegmentKind int
+
+const (
+	extractSegmentKey extractSegmentKind = iota
+	extractSegmentWildcard
+	extractSegmentIndex
+	extractSegmentFilter
+)
+
+type extractSegment struct {
+	kind   extractSegmentKind
+	key    string
+	index  int
+	filter *jmespath.JMESPath
+}
+
+func extractObject(old interface{}, segments []extractSegment) interface{} {
+	if len(segments) == 0 {
+		return old
+	}
+	segment, rest := segments[0], segments[1:]
+	switch segment.kind {
+	case extractSegmentKey:
+		if oldMap, ok := old.(map[string]interface{}); ok {
+			if value := extractObject(oldMap[segment.key], rest); value != nil {
+				return map[string]interface{}{
+					segment.key: value,
+				}
+			}
+		}
+	case extractSegmentWildcard:
+		switch oldValue := old.(type) {
+		case map[string]interface{}:
+			result := make(map[string]interface{})
+			for key, item := range oldValue {
+				if value := extractObject(item, rest); value != nil {
+					result[key] = value
+				}
 			}
-			return result
-		} else {
-			if oldMap[path] != nil {
-				result := make(map[string]interface{}, 1)
-				result[path] = oldMap[path]
+			if len(result) != 0 {
+				return result
+			}
+		case []interface{}:
+			result := make([]interface{}, len(oldValue))
+			found := false
+			for index, item := range oldValue {
+				if result[index] = extractObject(item, rest); result[index] != nil {
+					found = true
+				}
+			}
+			if found {
+				return result
+			}
+		}
+	case extractSegmentIndex:
+		if oldArr, ok := old.([]interface{}); ok {
+			index := segment.index
+			if index < 0 {
+				index += len(oldArr)
+			}
+			if index >= 0 && index < len(oldArr) {
+				if value := extractObject(oldArr[index], rest); value != nil {
+					result := make([]interface{}, index+1)
+					result[index] = value
+					return result
+				}
+			}
+		}
+	case extractSegmentFilter:
+		if oldArr, ok := old.([]interface{}); ok {
+			result := make([]interface{}, 0)
+			for _, item := range oldArr {
+				if matched, err := segment.filter.Search(item); err != nil || !isTruthy(matched) {
+					continue
+				}
+				if value := extractObject(item, rest); value != nil {
+					result = append(result, value)
+				}
+			}
+			if len(result) != 0 {
 				return result
-			} else {
-				return nil
 			}
 		}
 	}
 	return nil
 }
 
+// MergeExtractedObject merges the objects extracted by ExtractObject from the same object, the arrays are merged by
+// the positions of the elements, and the nil elements which aren't extracted don't override the other values.
+func MergeExtractedObject(old interface{}, new interface{}) interface{} {
+	if new == nil {
+		return old
+	}
+	switch oldValue := old.(type) {
+	case map[string]interface{}:
+		if newMap, ok := new.(map[string]interface{}); ok {
+			res := make(map[string]interface{})
+			for key, value := range oldValue {
+				res[key] = value
+			}
+			for key, value := range newMap {
+				res[key] = MergeExtractedObject(res[key], value)
+			}
+			return res
+		}
+	case []interface{}:
+		if newArr, ok := new.([]interface{}); ok {
+			res := make([]interface{}, max(len(oldValue), len(newArr)))
+			for index := range res {
+				var oldItem, newItem interface{}
+				if index < len(oldValue) {
+					oldItem = oldValue[index]
+				}
+				if index < len(newArr) {
+					newItem = newArr[index]
+				}
+				res[index] = MergeExtractedObject(oldItem, newItem)
+			}
+			return res
+		}
+	}
+	return new
+}
+
+// isTruthy follows the JMESPath rules, the false, null, empty strings, empty arrays and empty objects are false.
+func isTruthy(input interface{}) bool {
+	switch v := input.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) != 0
+	case map[string]interface{}:
+		return len(v) != 0
+	}
+	return true
+}
+
+// parseExtractPath splits the path into segments, the filters are evaluated by JMESPath.
+func parseExtractPath(path string) ([]extractSegment, error) {
+	segments := make([]extractSegment, 0)
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			if i == 0 || i == len(path)-1 || path[i+1] == '.' {
+				return nil, fmt.Errorf("invalid path %q: empty key at position %d", path, i)
+			}
+			i++
+		case '"':
+			end := closingQuote(path, i)
+			if end == -1 {
+				return nil, fmt.Errorf("invalid path %q: unclosed quote at position %d", path, i)
+			}
+			var key string
+			if err := json.Unmarshal([]byte(path[i:end+1]), &key); err != nil {
+				return nil, fmt.Errorf("invalid path %q: invalid quoted key %s", path, path[i:end+1])
+			}
+			segments = append(segments, extractSegment{kind: extractSegmentKey, key: key})
+			i = end + 1
+		case '[':
+			end := closingBracket(path, i)
+			if end == -1 {
+				return nil, fmt.Errorf("invalid path %q: unclosed bracket at position %d", path, i)
+			}
+			segment, err := parseExtractBracket(strings.TrimSpace(path[i+1 : end]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %+v", path, err)
+			}
+			segments = append(segments, *segment)
+			i = end + 1
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end == -1 {
+				end = len(path)
+			} else {
+				end += i
+			}
+			// the keys which aren't JMESPath identifiers are used as is, e.g. `primary-key`, `$schema` and `@odata`
+			key := strings.TrimSpace(path[i:end])
+			switch key {
+			case "":
+				return nil, fmt.Errorf("invalid path %q: empty key at position %d", path, i)
+			case "*":
+				segments = append(segments, extractSegment{kind: extractSegmentWildcard})
+			default:
+				segments = append(segments, extractSegment{kind: extractSegmentKey, key: key})
+			}
+			i = end
+		}
+	}
+	return segments, nil
+}
+
+func parseExtractBracket(input string) (*extractSegment, error) {
+	switch {
+	case input == "*":
+		return &extractSegment{kind: extractSegmentWildcard}, nil
+	case strings.HasPrefix(input, "?"):
+		filter, err := jmespath.Compile(input[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter [%s]: %+v", input, err)
+		}
+		return &extractSegment{kind: extractSegmentFilter, filter: filter}, nil
+	default:
+		index, err := strconv.Atoi(input)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported segment [%s], expect a wildcard, an index or a filter", input)
+		}
+		return &extractSegment{kind: extractSegmentIndex, index: index}, nil
+	}
+}
+
+// closingQuote returns the index of the quote which closes the quote at start, or -1 if it's not closed.
+func closingQuote(input string, start int) int {
+	for i := start + 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			i++
+		case input[start]:
+			return i
+		}
+	}
+	return -1
+}
+
+// closingBracket returns the index of the bracket which closes the bracket at start, or -1 if it's not closed.
+func closingBracket(input string, start int) int {
+	depth := 0
+	for i := start; i < len(input); i++ {
+		switch input[i] {
+		case '\'', '"', '`':
+			end := closingQuote(input, i)
+			if end == -1 {
+				return -1
+			}
+			i = end
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 // OverrideWithPaths is used to override old object with new object for specific paths
 func OverrideWithPaths(old interface{}, new interface{}, path string, pathSet map[string]bool) (interface{}, error) {
 	if len(pathSet) == 0 || old == nil {
@@ -200,6 +451,140 @@ func mergeArray(old []interface{}, new []interface{}) []interface{} {
 	return out
 }
 
+// RedactedValue is used to replace the sensitive values
+const RedactedValue = "REDACTED"
+
+// RedactObject is used to replace the values in input with RedactedValue, if the same path exists in sensitive
+func RedactObject(input interface{}, sensitive interface{}) interface{} {
+	if input == nil || sensitive == nil {
+		return input
+	}
+	switch sensitiveValue := sensitive.(type) {
+	case map[string]interface{}:
+		if inputMap, ok := input.(map[string]interface{}); ok {
+			res := make(map[string]interface{}, len(inputMap))
+			for key, value := range inputMap {
+				res[key] = RedactObject(value, sensitiveValue[key])
+			}
+			return res
+		}
+	case []interface{}:
+		if inputArr, ok := input.([]interface{}); ok && len(inputArr) == len(sensitiveValue) {
+			res := make([]interface{}, len(inputArr))
+			for i, value := range inputArr {
+				res[i] = RedactObject(value, sensitiveValue[i])
+			}
+			return res
+		}
+	}
+	return RedactedValue
+}
+
+// DiffObject is used to compare two objects and returns the changes of each property, the properties which only exist
+// in one of the objects are also returned. Each change is in a format like `path: before => after`
+func DiffObject(before interface{}, after interface{}) []string {
+	return DiffObjectWithOption(before, after, UpdateJsonOption{})
+}
+
+// DiffObjectWithOption is the same as DiffObject, but the strings which only differ in casing are not changed if IgnoreCasing
+// is true, and the properties which only exist in one of the objects and the arrays with different lengths are not compared
+// if IgnoreMissingProperty is true.
+func DiffObjectWithOption(before interface{}, after interface{}, option UpdateJsonOption) []string {
+	changes := diffObject(before, after, "", option)
+	sort.Strings(changes)
+	return changes
+}
+
+func diffObject(before interface{}, after interface{}, path string, option UpdateJsonOption) []string {
+	if option.IgnoreMissingProperty && (before == nil || after == nil) {
+		return []string{}
+	}
+	switch beforeValue := before.(type) {
+	case map[string]interface{}:
+		if afterMap, ok := after.(map[string]interface{}); ok {
+			changes := make([]string, 0)
+			for key, value := range beforeValue {
+				childPath := key
+				if path != "" {
+					childPath = path + "." + key
+				}
+				if afterValue, ok := afterMap[key]; ok {
+					changes = append(changes, diffObject(value, afterValue, childPath, option)...)
+				} else if !option.IgnoreMissingProperty {
+					changes = append(changes, fmt.Sprintf("%s: %s => (not specified)", childPath, formatValue(value)))
+				}
+			}
+			for key, value := range afterMap {
+				childPath := key
+				if path != "" {
+					childPath = path + "." + key
+				}
+				if _, ok := beforeValue[key]; !ok && !option.IgnoreMissingProperty {
+					changes = append(changes, fmt.Sprintf("%s: (not specified) => %s", childPath, formatValue(value)))
+				}
+			}
+			return changes
+		}
+	case []interface{}:
+		if afterArr, ok := after.([]interface{}); ok {
+			if len(afterArr) == len(beforeValue) {
+				changes := make([]string, 0)
+				for i := range beforeValue {
+					changes = append(changes, diffObject(beforeValue[i], afterArr[i], fmt.Sprintf("%s[%d]", path, i), option)...)
+				}
+				return changes
+			}
+			if option.IgnoreMissingProperty {
+				// the items are added or removed rather than changed
+				return []string{}
+			}
+		}
+	case string:
+		if afterStr, ok := after.(string); ok && option.IgnoreCasing && strings.EqualFold(beforeValue, afterStr) {
+			return []string{}
+		}
+	}
+	if reflect.DeepEqual(before, after) {
+		return []string{}
+	}
+	return []string{fmt.Sprintf("%s: %s => %s", path, formatValue(before), formatValue(after))}
+}
+
+// PatchObject is used to build the body of a PATCH request which changes old to new, it only contains the top level
+// properties which are changed, and each of them is sent as a whole. The nested properties aren't diffed, because
+// the dictionaries like `tags` are replaced instead of merged by the PATCH requests. The removed properties are set to
+// nil, which deletes them in the JSON merge patch.
+func PatchObject(old interface{}, new interface{}) interface{} {
+	oldMap, ok := old.(map[string]interface{})
+	if !ok {
+		return new
+	}
+	newMap, ok := new.(map[string]interface{})
+	if !ok {
+		return new
+	}
+	res := make(map[string]interface{})
+	for key, value := range newMap {
+		if oldValue, ok := oldMap[key]; !ok || !reflect.DeepEqual(oldValue, value) {
+			res[key] = value
+		}
+	}
+	for key := range oldMap {
+		if _, ok := newMap[key]; !ok {
+			res[key] = nil
+		}
+	}
+	return res
+}
+
+func formatValue(input interface{}) string {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Sprintf("%v", input)
+	}
+	return string(data)
+}
+
 // NormalizeObject is used to remove customized type and replaced with builtin type
 func NormalizeObject(input interface{}) interface{} {
 	jsonString, _ := json.Marshal(input)
@@ -357,6 +357,252 @@ func Test_ExtractObject(t *testing.T) {
 	}
 }
 
+func Test_ExtractObjectWithPathExpressions(t *testing.T) {
+	oldJson := `
+{
+  "tags": {
+    "hidden-link:/app.insights": "Resource",
+    "hidden-link": "link",
+    "env": "prod"
+  },
+  "properties": {
+    "primary-key": "key",
+    "outputs": {"$schema": "schema"},
+    "@odata": {"type": "odata"},
+    "subnets": [
+      {
+        "name": "default",
+        "id": "subnet1",
+        "properties": {"addressPrefix": "10.0.0.0/24", "delegated": false}
+      },
+      {
+        "name": "backend",
+        "id": "subnet2",
+        "properties": {"addressPrefix": "10.0.1.0/24", "delegated": true}
+      }
+    ],
+    "ipConfigurations": [
+      {
+        "properties": {"privateIPAddress": "10.0.0.4"}
+      }
+    ]
+  }
+}
+`
+	testcases := []struct {
+		Path       string
+		ExpectJson string
+	}{
+		{
+			Path:       "properties.ipConfigurations[0].properties.privateIPAddress",
+			ExpectJson: `{"properties": {"ipConfigurations": [{"properties": {"privateIPAddress": "10.0.0.4"}}]}}`,
+		},
+		{
+			Path:       "properties.subnets[-1].name",
+			ExpectJson: `{"properties": {"subnets": [null, {"name": "backend"}]}}`,
+		},
+		{
+			Path:       "properties.subnets[*].id",
+			ExpectJson: `{"properties": {"subnets": [{"id": "subnet1"}, {"id": "subnet2"}]}}`,
+		},
+		{
+			Path:       "properties.subnets.*.properties.addressPrefix",
+			ExpectJson: `{"properties": {"subnets": [{"properties": {"addressPrefix": "10.0.0.0/24"}}, {"properties": {"addressPrefix": "10.0.1.0/24"}}]}}`,
+		},
+		{
+			Path:       "properties.subnets[?name=='default'].id",
+			ExpectJson: `{"properties": {"subnets": [{"id": "subnet1"}]}}`,
+		},
+		{
+			Path:       "properties.subnets[?properties.delegated == `true`]",
+			ExpectJson: `{"properties": {"subnets": [{"name": "backend", "id": "subnet2", "properties": {"addressPrefix": "10.0.1.0/24", "delegated": true}}]}}`,
+		},
+		{
+			Path:       "properties.subnets[?name != 'default'].name",
+			ExpectJson: `{"properties": {"subnets": [{"name": "backend"}]}}`,
+		},
+		{
+			Path:       `tags."hidden-link:/app.insights"`,
+			ExpectJson: `{"tags": {"hidden-link:/app.insights": "Resource"}}`,
+		},
+		{
+			Path:       "properties.subnets[?properties.delegated].name",
+			ExpectJson: `{"properties": {"subnets": [{"name": "backend"}]}}`,
+		},
+		{
+			Path:       "properties.subnets[?starts_with(name, 'back')].id",
+			ExpectJson: `{"properties": {"subnets": [{"id": "subnet2"}]}}`,
+		},
+		{
+			Path:       "tags.*",
+			ExpectJson: `{"tags": {"hidden-link:/app.insights": "Resource", "hidden-link": "link", "env": "prod"}}`,
+		},
+		{
+			Path:       "tags.hidden-link",
+			ExpectJson: `{"tags": {"hidden-link": "link"}}`,
+		},
+		{
+			Path:       "properties.primary-key",
+			ExpectJson: `{"properties": {"primary-key": "key"}}`,
+		},
+		{
+			Path:       "properties.outputs.$schema",
+			ExpectJson: `{"properties": {"outputs": {"$schema": "schema"}}}`,
+		},
+		{
+			Path:       "properties.@odata.type",
+			ExpectJson: `{"properties": {"@odata": {"type": "odata"}}}`,
+		},
+		{
+			Path:       "properties.subnets[2].id",
+			ExpectJson: `null`,
+		},
+		{
+			Path:       "properties.subnets[?name=='frontend'].id",
+			ExpectJson: `null`,
+		},
+		{
+			Path:       "properties.subnets[0",
+			ExpectJson: `null`,
+		},
+		{
+			Path:       "properties..subnets",
+			ExpectJson: `null`,
+		},
+		{
+			Path:       "tags['hidden-link:/app.insights']",
+			ExpectJson: `null`,
+		},
+		{
+			Path:       "properties.subnets[*].id | [0]",
+			ExpectJson: `null`,
+		},
+	}
+
+	var old interface{}
+	_ = json.Unmarshal([]byte(oldJson), &old)
+	for _, testcase := range testcases {
+		t.Logf("[DEBUG] Testing path: %s", testcase.Path)
+		var expected interface{}
+		_ = json.Unmarshal([]byte(testcase.ExpectJson), &expected)
+		result := utils.ExtractObject(old, testcase.Path)
+		if !reflect.DeepEqual(result, expected) {
+			expectedJson, _ := json.Marshal(expected)
+			resultJson, _ := json.Marshal(result)
+			t.Fatalf("Expected %s but got %s", expectedJson, resultJson)
+		}
+	}
+}
+
+func Test_MergeExtractedObject(t *testing.T) {
+	oldJson := `
+{
+  "properties": {
+    "subnets": [
+      {"id": "a", "name": "x"},
+      {"id": "b", "name": "y"}
+    ],
+    "primary-key": "key"
+  }
+}
+`
+	testcases := []struct {
+		Paths      []string
+		ExpectJson string
+	}{
+		{
+			Paths:      []string{"properties.subnets[0].id", "properties.subnets[1].name"},
+			ExpectJson: `{"properties": {"subnets": [{"id": "a"}, {"name": "y"}]}}`,
+		},
+		{
+			Paths:      []string{"properties.subnets[1].name", "properties.subnets[0].id"},
+			ExpectJson: `{"properties": {"subnets": [{"id": "a"}, {"name": "y"}]}}`,
+		},
+		{
+			Paths:      []string{"properties.subnets[*].id", "properties.subnets[-1].name", "properties.primary-key"},
+			ExpectJson: `{"properties": {"subnets": [{"id": "a"}, {"id": "b", "name": "y"}], "primary-key": "key"}}`,
+		},
+	}
+
+	var old interface{}
+	_ = json.Unmarshal([]byte(oldJson), &old)
+	for _, testcase := range testcases {
+		t.Logf("[DEBUG] Testing paths: %v", testcase.Paths)
+		var expected interface{}
+		_ = json.Unmarshal([]byte(testcase.ExpectJson), &expected)
+		var result interface{} = map[string]interface{}{}
+		for _, path := range testcase.Paths {
+			result = utils.MergeExtractedObject(result, utils.ExtractObject(old, path))
+		}
+		if !reflect.DeepEqual(result, expected) {
+			expectedJson, _ := json.Marshal(expected)
+			resultJson, _ := json.Marshal(result)
+			t.Fatalf("Expected %s but got %s", expectedJson, resultJson)
+		}
+	}
+}
+
+func Test_ValidateExtractPath(t *testing.T) {
+	testcases := []struct {
+		Path      string
+		ExpectErr bool
+	}{
+		{
+			Path: "properties.subnets[?name=='default'].properties.addressPrefix",
+		},
+		{
+			Path: `tags."hidden-link:/app.insights"`,
+		},
+		{
+			Path: "properties.subnets[-1].*",
+		},
+		{
+			Path:      "properties.subnets[0",
+			ExpectErr: true,
+		},
+		{
+			Path:      "properties..subnets",
+			ExpectErr: true,
+		},
+		{
+			Path:      "properties.subnets[?name = 'default']",
+			ExpectErr: true,
+		},
+		{
+			Path:      "properties.subnets[0:2]",
+			ExpectErr: true,
+		},
+		{
+			Path:      "properties.subnets[name]",
+			ExpectErr: true,
+		},
+		{
+			Path:      ".properties",
+			ExpectErr: true,
+		},
+		{
+			Path: "tags.hidden-link",
+		},
+		{
+			Path: "properties.primary-key",
+		},
+		{
+			Path: "properties.outputs.$schema",
+		},
+		{
+			Path: "@odata.type",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Logf("[DEBUG] Testing path: %s", testcase.Path)
+		err := utils.ValidateExtractPath(testcase.Path)
+		if (err != nil) != testcase.ExpectErr {
+			t.Fatalf("Expected error: %t but got %+v", testcase.ExpectErr, err)
+		}
+	}
+}
+
 func Test_OverrideWithPaths(t *testing.T) {
 	testcases := []struct {
 		OldJson       string
@@ -455,3 +701,272 @@ func Test_OverrideWithPaths(t *testing.T) {
 		}
 	}
 }
+
+func Test_RedactObject(t *testing.T) {
+	inputJson := `
+{
+  "name": "myServer",
+  "properties": {
+    "administratorLogin": "admin",
+    "administratorLoginPassword": "secret",
+    "connectionStrings": [
+      {
+        "name": "db1",
+        "value": "secret1"
+      },
+      {
+        "name": "db2",
+        "value": "secret2"
+      }
+    ],
+    "credentials": {
+      "key1": "secret3",
+      "key2": "secret4"
+    }
+  }
+}
+`
+	sensitiveJson := `
+{
+  "properties": {
+    "administratorLoginPassword": "secret",
+    "connectionStrings": [
+      null,
+      {
+        "value": "secret2"
+      }
+    ],
+    "credentials": {
+      "key1": "secret3",
+      "key2": "secret4"
+    }
+  }
+}
+`
+	expectedJson := `
+{
+  "name": "myServer",
+  "properties": {
+    "administratorLogin": "admin",
+    "administratorLoginPassword": "REDACTED",
+    "connectionStrings": [
+      {
+        "name": "db1",
+        "value": "secret1"
+      },
+      {
+        "name": "db2",
+        "value": "REDACTED"
+      }
+    ],
+    "credentials": {
+      "key1": "REDACTED",
+      "key2": "REDACTED"
+    }
+  }
+}
+`
+
+	var input, sensitive, expected interface{}
+	_ = json.Unmarshal([]byte(inputJson), &input)
+	_ = json.Unmarshal([]byte(sensitiveJson), &sensitive)
+	_ = json.Unmarshal([]byte(expectedJson), &expected)
+
+	result := utils.RedactObject(input, sensitive)
+	if !reflect.DeepEqual(result, expected) {
+		expectedJson, _ := json.Marshal(expected)
+		resultJson, _ := json.Marshal(result)
+		t.Fatalf("Expected %s but got %s", expectedJson, resultJson)
+	}
+
+	// no sensitive values
+	result = utils.RedactObject(input, nil)
+	if !reflect.DeepEqual(result, input) {
+		inputJson, _ := json.Marshal(input)
+		resultJson, _ := json.Marshal(result)
+		t.Fatalf("Expected %s but got %s", inputJson, resultJson)
+	}
+}
+
+func Test_DiffObject(t *testing.T) {
+	beforeJson := `
+{
+  "location": "westeurope",
+  "sku": {
+    "name": "Basic",
+    "capacity": 1
+  },
+  "properties": {
+    "publicNetworkAccess": "Enabled",
+    "ipRules": [
+      "10.0.0.1",
+      "10.0.0.2"
+    ]
+  }
+}
+`
+	afterJson := `
+{
+  "location": "westeurope",
+  "sku": {
+    "name": "Standard",
+    "capacity": 1
+  },
+  "properties": {
+    "ipRules": [
+      "10.0.0.1",
+      "10.0.0.3"
+    ],
+    "disableLocalAuth": true
+  }
+}
+`
+	expected := []string{
+		`properties.disableLocalAuth: (not specified) => true`,
+		`properties.ipRules[1]: "10.0.0.2" => "10.0.0.3"`,
+		`properties.publicNetworkAccess: "Enabled" => (not specified)`,
+		`sku.name: "Basic" => "Standard"`,
+	}
+
+	var before, after interface{}
+	_ = json.Unmarshal([]byte(beforeJson), &before)
+	_ = json.Unmarshal([]byte(afterJson), &after)
+
+	result := utils.DiffObject(before, after)
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	// no changes
+	result = utils.DiffObject(before, before)
+	if len(result) != 0 {
+		t.Fatalf("Expected no changes but got %v", result)
+	}
+}
+
+func Test_DiffObjectWithOption(t *testing.T) {
+	beforeJson := `
+{
+  "properties": {
+    "osProfile": {
+      "adminUsername": "AdminUser",
+      "computerName": "vm1"
+    },
+    "zones": ["1", "2"],
+    "dataDisks": [{"lun": 0}]
+  }
+}
+`
+	afterJson := `
+{
+  "properties": {
+    "osProfile": {
+      "adminUsername": "adminuser",
+      "customData": "data"
+    },
+    "zones": ["1"],
+    "dataDisks": [{"lun": 1}]
+  }
+}
+`
+	var before, after interface{}
+	_ = json.Unmarshal([]byte(beforeJson), &before)
+	_ = json.Unmarshal([]byte(afterJson), &after)
+
+	expected := []string{`properties.dataDisks[0].lun: 0 => 1`}
+	result := utils.DiffObjectWithOption(before, after, utils.UpdateJsonOption{IgnoreCasing: true, IgnoreMissingProperty: true})
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	expected = []string{
+		`properties.dataDisks[0].lun: 0 => 1`,
+		`properties.osProfile.adminUsername: "AdminUser" => "adminuser"`,
+		`properties.osProfile.computerName: "vm1" => (not specified)`,
+		`properties.osProfile.customData: (not specified) => "data"`,
+		`properties.zones: ["1","2"] => ["1"]`,
+	}
+	result = utils.DiffObjectWithOption(before, after, utils.UpdateJsonOption{})
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+}
+
+func Test_PatchObject(t *testing.T) {
+	oldJson := `
+{
+  "location": "westeurope",
+  "sku": {
+    "name": "Standard"
+  },
+  "tags": {
+    "env": "dev",
+    "owner": "team-a"
+  },
+  "properties": {
+    "publicNetworkAccess": "Enabled",
+    "ipRules": [
+      "10.0.0.1",
+      "10.0.0.2"
+    ],
+    "encryption": {
+      "status": "Disabled"
+    }
+  }
+}
+`
+	newJson := `
+{
+  "location": "westeurope",
+  "tags": {
+    "env": "prod",
+    "owner": "team-a"
+  },
+  "properties": {
+    "ipRules": [
+      "10.0.0.1",
+      "10.0.0.3"
+    ],
+    "encryption": {
+      "status": "Disabled"
+    },
+    "disableLocalAuth": true
+  }
+}
+`
+	expectedJson := `
+{
+  "sku": null,
+  "tags": {
+    "env": "prod",
+    "owner": "team-a"
+  },
+  "properties": {
+    "ipRules": [
+      "10.0.0.1",
+      "10.0.0.3"
+    ],
+    "encryption": {
+      "status": "Disabled"
+    },
+    "disableLocalAuth": true
+  }
+}
+`
+
+	var old, new, expected interface{}
+	_ = json.Unmarshal([]byte(oldJson), &old)
+	_ = json.Unmarshal([]byte(newJson), &new)
+	_ = json.Unmarshal([]byte(expectedJson), &expected)
+
+	result := utils.PatchObject(old, new)
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Expected %v but got %v", expected, result)
+	}
+
+	// no changes
+	result = utils.PatchObject(old, old)
+	if !reflect.DeepEqual(result, map[string]interface{}{}) {
+		t.Fatalf("Expected no changes but got %v", result)
+	}
+}
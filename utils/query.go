@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"github.com/jmespath/go-jmespath"
+)
+
+// QueryObject evaluates a JMESPath expression against the input object and returns the result.
+func QueryObject(input interface{}, expression string) (interface{}, error) {
+	query, err := jmespath.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	return query.Search(input)
+}
+
+// ValidateQuery checks whether the expression is a valid JMESPath expression.
+func ValidateQuery(expression string) error {
+	_, err := jmespath.Compile(expression)
+	return err
+}
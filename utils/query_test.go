@@ -0,0 +1,173 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/utils"
+)
+
+func Test_QueryObject(t *testing.T) {
+	input := `
+{
+  "value": [
+    {
+      "name": "a1",
+      "location": "westeurope",
+      "tags": {"env": "prod"},
+      "properties": {"priority": 100, "addressPrefixes": ["10.0.0.0/24", "10.0.1.0/24"]}
+    },
+    {
+      "name": "a2",
+      "location": "eastus",
+      "tags": {"env": "dev"},
+      "properties": {"priority": 200, "addressPrefixes": ["10.1.0.0/24"]}
+    },
+    {
+      "name": "b1",
+      "location": "westeurope",
+      "properties": {"priority": 300, "addressPrefixes": []}
+    }
+  ],
+  "nextLink": null
+}
+`
+	testcases := []struct {
+		Expression string
+		ExpectJson string
+		ExpectErr  bool
+	}{
+		{
+			Expression: "value[0].name",
+			ExpectJson: `"a1"`,
+		},
+		{
+			Expression: "value[-1].name",
+			ExpectJson: `"b1"`,
+		},
+		{
+			Expression: "value[10].name",
+			ExpectJson: `null`,
+		},
+		{
+			Expression: "value[*].name",
+			ExpectJson: `["a1", "a2", "b1"]`,
+		},
+		{
+			Expression: "value[1:].name",
+			ExpectJson: `["a2", "b1"]`,
+		},
+		{
+			Expression: "value[::-1].name",
+			ExpectJson: `["b1", "a2", "a1"]`,
+		},
+		{
+			Expression: "value[*].tags.env",
+			ExpectJson: `["prod", "dev"]`,
+		},
+		{
+			Expression: "value[?location == 'westeurope'].name",
+			ExpectJson: `["a1", "b1"]`,
+		},
+		{
+			Expression: "value[?properties.priority > `150` && tags.env != 'prod'].name",
+			ExpectJson: `["a2", "b1"]`,
+		},
+		{
+			Expression: "value[?starts_with(name, 'a') || !tags].{name: name, env: tags.env}",
+			ExpectJson: `[{"name": "a1", "env": "prod"}, {"name": "a2", "env": "dev"}, {"name": "b1", "env": null}]`,
+		},
+		{
+			Expression: "value[*].properties.addressPrefixes[]",
+			ExpectJson: `["10.0.0.0/24", "10.0.1.0/24", "10.1.0.0/24"]`,
+		},
+		{
+			Expression: "value[*].[name, location]",
+			ExpectJson: `[["a1", "westeurope"], ["a2", "eastus"], ["b1", "westeurope"]]`,
+		},
+		{
+			Expression: "value[?contains(properties.addressPrefixes, '10.1.0.0/24')].name | [0]",
+			ExpectJson: `"a2"`,
+		},
+		{
+			Expression: "length(value)",
+			ExpectJson: `3`,
+		},
+		{
+			Expression: "sum(value[*].properties.priority)",
+			ExpectJson: `600`,
+		},
+		{
+			Expression: "join(',', sort(value[*].location))",
+			ExpectJson: `"eastus,westeurope,westeurope"`,
+		},
+		{
+			Expression: "value[0].tags.*",
+			ExpectJson: `["prod"]`,
+		},
+		{
+			Expression: `value[0]."properties".priority`,
+			ExpectJson: `100`,
+		},
+		{
+			Expression: "value[?name == `\"a1\"`] | [0].properties",
+			ExpectJson: `{"priority": 100, "addressPrefixes": ["10.0.0.0/24", "10.0.1.0/24"]}`,
+		},
+		{
+			Expression: "sort_by(value, &properties.priority)[::-1].name",
+			ExpectJson: `["b1", "a2", "a1"]`,
+		},
+		{
+			Expression: "max_by(value, &properties.priority).name",
+			ExpectJson: `"b1"`,
+		},
+		{
+			Expression: "map(&name, value)",
+			ExpectJson: `["a1", "a2", "b1"]`,
+		},
+		{
+			Expression: "value[",
+			ExpectErr:  true,
+		},
+		{
+			Expression: "value[?name = 'a1']",
+			ExpectErr:  true,
+		},
+		{
+			Expression: "unknown(value)",
+			ExpectErr:  true,
+		},
+		{
+			Expression: "length(value, value)",
+			ExpectErr:  true,
+		},
+		{
+			Expression: "length(`1`)",
+			ExpectErr:  true,
+		},
+	}
+
+	var inputObj interface{}
+	_ = json.Unmarshal([]byte(input), &inputObj)
+	for _, testcase := range testcases {
+		t.Logf("[DEBUG] Testing expression: %s", testcase.Expression)
+		result, err := utils.QueryObject(inputObj, testcase.Expression)
+		if testcase.ExpectErr {
+			if err == nil {
+				t.Fatalf("Expected error but got none")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		var expected interface{}
+		_ = json.Unmarshal([]byte(testcase.ExpectJson), &expected)
+		if !reflect.DeepEqual(result, expected) {
+			expectedJson, _ := json.Marshal(expected)
+			resultJson, _ := json.Marshal(result)
+			t.Fatalf("Expected %s but got %s", expectedJson, resultJson)
+		}
+	}
+}
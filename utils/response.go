@@ -11,6 +11,10 @@ func ResponseErrorWasNotFound(err error) bool {
 	return ResponseErrorWasStatusCode(err, http.StatusNotFound)
 }
 
+func ResponseErrorWasPreconditionFailed(err error) bool {
+	return ResponseErrorWasStatusCode(err, http.StatusPreconditionFailed)
+}
+
 func ResponseErrorWasStatusCode(err error, statusCode int) bool {
 	var responseErr *azcore.ResponseError
 	return errors.As(err, &responseErr) && responseErr.StatusCode == statusCode